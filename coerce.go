@@ -0,0 +1,253 @@
+package graphql
+
+import (
+	"fmt"
+	"reflect"
+
+	schema "github.com/WilsonGiese/graphql/schema"
+)
+
+// coerceVariableValues coerces the values provided for an Operation's
+// VariableDefinitions to their declared types, applying any default values
+func (e *executor) coerceVariableValues(operation Operation, inputs map[string]interface{}) (map[string]interface{}, error) {
+	coerced := make(map[string]interface{})
+
+	for _, definition := range operation.VariableDefinitions {
+		t := schema.DescribeASTType(definition.Type)
+
+		value, provided := inputs[definition.Name]
+		if !provided {
+			if definition.Default != nil {
+				defaultValue, err := e.valueFromAST(definition.Default, t)
+				if err != nil {
+					return nil, fmt.Errorf("Variable '$%s' has an invalid default value: %s", definition.Name, err)
+				}
+				coerced[definition.Name] = defaultValue
+			} else if t.NonNull {
+				return nil, fmt.Errorf("Variable '$%s' of required type '%s' was not provided", definition.Name, t)
+			}
+			continue
+		}
+
+		coercedValue, err := e.coerceInputValue(value, t)
+		if err != nil {
+			return nil, fmt.Errorf("Variable '$%s' got invalid value: %s", definition.Name, err)
+		}
+		coerced[definition.Name] = coercedValue
+	}
+	return coerced, nil
+}
+
+// coerceArgumentValues coerces the values given to a field or directive to the
+// types of the declared arguments, applying any default values
+func (e *executor) coerceArgumentValues(arguments map[string]schema.Argument, values map[string]Argument) (map[string]interface{}, error) {
+	coerced := make(map[string]interface{})
+
+	for name, argument := range arguments {
+		given, provided := values[name]
+		value := given.Value
+
+		// A variable without a value is treated as if the argument was omitted
+		if variable, isVariable := value.(Variable); provided && isVariable {
+			_, provided = e.variables[variable.Name]
+		}
+
+		if !provided {
+			if argument.Default != nil {
+				coerced[name] = argument.Default
+			} else if argument.Type.NonNull {
+				return nil, fmt.Errorf("Argument '%s' of required type '%s' was not provided", name, argument.Type)
+			}
+			continue
+		}
+
+		coercedValue, err := e.valueFromAST(value, argument.Type)
+		if err != nil {
+			return nil, fmt.Errorf("Argument '%s' got invalid value: %s", name, err)
+		}
+		coerced[name] = coercedValue
+	}
+	return coerced, nil
+}
+
+// valueFromAST coerces a Value from a Document to the type t
+func (e *executor) valueFromAST(value Value, t schema.Type) (interface{}, error) {
+	if variable, isVariable := value.(Variable); isVariable {
+		coerced := e.variables[variable.Name]
+		if t.NonNull && coerced == nil {
+			return nil, fmt.Errorf("expected non-null value of type '%s' but variable '$%s' is null", t, variable.Name)
+		}
+		return coerced, nil
+	}
+
+	if _, isNull := value.(NullValue); isNull {
+		if t.NonNull {
+			return nil, fmt.Errorf("expected non-null value of type '%s' but found null", t)
+		}
+		return nil, nil
+	}
+
+	if t.List {
+		list, isList := value.(ListValue)
+
+		// A single value is coerced to a list containing only that value
+		if !isList {
+			item, err := e.valueFromAST(value, *t.SubType)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{item}, nil
+		}
+
+		coerced := make([]interface{}, len(list.Values))
+		for i, item := range list.Values {
+			coercedItem, err := e.valueFromAST(item, *t.SubType)
+			if err != nil {
+				return nil, err
+			}
+			coerced[i] = coercedItem
+		}
+		return coerced, nil
+	}
+
+	switch declaration := e.schema.GetDeclaration(t).(type) {
+	case schema.Scalar:
+		return schema.ParseScalarLiteral(declaration, value, e.variables)
+	case schema.Enum:
+		if literal, isEnum := value.(EnumValue); isEnum {
+			if enumValue, exists := declaration.GetValue(literal.Value); exists {
+				return enumValue.GoValue(), nil
+			}
+		}
+	case schema.Input:
+		if object, isObject := value.(ObjectValue); isObject {
+			coerced := make(map[string]interface{})
+			for name := range object.Fields {
+				if _, exists := declaration.Fields[name]; !exists {
+					return nil, fmt.Errorf("field '%s' is not defined by type '%s'", name, declaration.Name)
+				}
+			}
+
+			for name, field := range declaration.Fields {
+				fieldValue, provided := object.Fields[name]
+				if !provided {
+					if field.Default != nil {
+						coerced[name] = field.Default
+					} else if field.Type.NonNull {
+						return nil, fmt.Errorf("field '%s' of required type '%s' was not provided", name, field.Type)
+					}
+					continue
+				}
+
+				coercedField, err := e.valueFromAST(fieldValue.Value, field.Type)
+				if err != nil {
+					return nil, err
+				}
+				coerced[name] = coercedField
+			}
+			return coerced, nil
+		}
+	}
+	return nil, fmt.Errorf("expected value of type '%s' but found %s", t, value)
+}
+
+// coerceInputValue coerces a value provided for a variable, such as a value
+// decoded from JSON, to the type t
+func (e *executor) coerceInputValue(value interface{}, t schema.Type) (interface{}, error) {
+	if isNil(value) {
+		if t.NonNull {
+			return nil, fmt.Errorf("expected non-null value of type '%s' but found null", t)
+		}
+		return nil, nil
+	}
+
+	if t.List {
+		list := reflect.ValueOf(value)
+
+		// A single value is coerced to a list containing only that value
+		if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+			item, err := e.coerceInputValue(value, *t.SubType)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{item}, nil
+		}
+
+		coerced := make([]interface{}, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			item, err := e.coerceInputValue(list.Index(i).Interface(), *t.SubType)
+			if err != nil {
+				return nil, err
+			}
+			coerced[i] = item
+		}
+		return coerced, nil
+	}
+
+	switch declaration := e.schema.GetDeclaration(t).(type) {
+	case schema.Scalar:
+		return parseScalarValue(declaration, value)
+	case schema.Enum:
+		if name, isString := value.(string); isString {
+			if enumValue, exists := declaration.GetValue(name); exists {
+				return enumValue.GoValue(), nil
+			}
+		}
+	case schema.Input:
+		if object, isObject := value.(map[string]interface{}); isObject {
+			coerced := make(map[string]interface{})
+			for name := range object {
+				if _, exists := declaration.Fields[name]; !exists {
+					return nil, fmt.Errorf("field '%s' is not defined by type '%s'", name, declaration.Name)
+				}
+			}
+
+			for name, field := range declaration.Fields {
+				fieldValue, provided := object[name]
+				if !provided {
+					if field.Default != nil {
+						coerced[name] = field.Default
+					} else if field.Type.NonNull {
+						return nil, fmt.Errorf("field '%s' of required type '%s' was not provided", name, field.Type)
+					}
+					continue
+				}
+
+				coercedField, err := e.coerceInputValue(fieldValue, field.Type)
+				if err != nil {
+					return nil, err
+				}
+				coerced[name] = coercedField
+			}
+			return coerced, nil
+		}
+	}
+	return nil, fmt.Errorf("expected value of type '%s' but found %v", t, value)
+}
+
+// parseScalarValue parses a value provided for a variable as a value of the
+// scalar
+func parseScalarValue(scalar schema.Scalar, value interface{}) (interface{}, error) {
+	if scalar.ParseValue != nil {
+		return scalar.ParseValue(value)
+	}
+	return value, nil
+}
+
+// serializeScalar converts a resolved value to the response representation of
+// the scalar
+func serializeScalar(scalar schema.Scalar, value interface{}) (interface{}, error) {
+	if scalar.Serialize != nil {
+		return scalar.Serialize(value)
+	}
+	return value, nil
+}
+
+// serializeEnum converts a resolved Go value to the name of the Enum value it
+// maps to
+func serializeEnum(enum schema.Enum, value interface{}) (interface{}, error) {
+	if enumValue, exists := enum.ValueOf(value); exists {
+		return enumValue.Name, nil
+	}
+	return nil, fmt.Errorf("Cannot serialize %v as type '%s'", value, enum.Name)
+}
@@ -0,0 +1,476 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	schema "github.com/WilsonGiese/graphql/schema"
+)
+
+// Result is the outcome of executing an Operation. Data contains the values of
+// every selected field, and Errors contains any errors that occurred while
+// producing them
+type Result struct {
+	Data   ResultMap         `json:"data"`
+	Errors []*ExecutionError `json:"errors,omitempty"`
+}
+
+// ResultMap holds the values of the fields selected on an object by response
+// key, in the order the fields were selected. It is encoded as a JSON object
+// whose keys keep that order
+type ResultMap []ResultField
+
+// ResultField is the value of a single response key of a ResultMap
+type ResultField struct {
+	Key   string
+	Value interface{}
+}
+
+// Get returns the value of the response key, or false if it was not selected
+func (m ResultMap) Get(key string) (interface{}, bool) {
+	for _, field := range m {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// MarshalJSON encodes the ResultMap as a JSON object with its keys in order. A
+// nil ResultMap is encoded as null
+func (m ResultMap) MarshalJSON() ([]byte, error) {
+	if m == nil {
+		return []byte("null"), nil
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, field := range m {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+
+		key, err := json.Marshal(field.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(key)
+		buffer.WriteByte(':')
+		buffer.Write(value)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+// ExecutionError represents an error that occurred while executing an
+// Operation. Path describes the response field the error occurred on, and
+// Locations are the locations of the fields selecting it in the Document. Both
+// are empty if the error prevented execution from starting
+type ExecutionError struct {
+	Message   string        `json:"message"`
+	Locations []Loc         `json:"-"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+func (err *ExecutionError) Error() string {
+	return err.Message
+}
+
+type executor struct {
+	ctx       context.Context
+	schema    *schema.Schema
+	document  *Document
+	variables map[string]interface{}
+	errors    []*ExecutionError
+}
+
+// Execute executes an Operation from the document against the schema. The
+// operationName may be empty if the document contains only one Operation.
+// Variables provides the values for the Operation's VariableDefinitions, and
+// rootValue is the Source given to the resolvers of the root type's Fields.
+// The document is assumed to have been validated against the schema
+func Execute(s *schema.Schema, document *Document, operationName string, variables map[string]interface{}, rootValue interface{}) *Result {
+	return ExecuteContext(context.Background(), s, document, operationName, variables, rootValue)
+}
+
+// ExecuteContext is like Execute, but gives ctx to the ResolveFunc of every
+// Field so resolvers can observe cancellation and request scoped values
+func ExecuteContext(ctx context.Context, s *schema.Schema, document *Document, operationName string, variables map[string]interface{}, rootValue interface{}) *Result {
+	e := executor{
+		ctx:      ctx,
+		schema:   s,
+		document: document,
+	}
+
+	operation, rootType, err := e.prepare(operationName, variables)
+	if err == nil && operation.Type == "subscription" {
+		err = errors.New("Subscription operations must be executed with Subscribe")
+	}
+	if err != nil {
+		e.error(nil, nil, "%s", err)
+		return &Result{Errors: e.errors}
+	}
+
+	// Fields are always executed in order, which also satisfies the serial
+	// execution mutations require
+	data, _ := e.executeSelectionSet(operation.SelectionSet, rootType, rootValue, nil)
+	return &Result{Data: data, Errors: e.errors}
+}
+
+// prepare selects the Operation to execute along with its root type, and
+// coerces the values of its variables. Returns an error if the Operation
+// cannot be executed
+func (e *executor) prepare(operationName string, variables map[string]interface{}) (Operation, schema.Object, error) {
+	operation, err := e.document.GetOperation(operationName)
+	if err != nil {
+		return Operation{}, schema.Object{}, err
+	}
+
+	rootType, supported := operationRootType(operation, e.schema)
+	if !supported {
+		return Operation{}, schema.Object{}, fmt.Errorf("Schema does not support %s operations", operation.Type)
+	}
+
+	if e.variables, err = e.coerceVariableValues(operation, variables); err != nil {
+		return Operation{}, schema.Object{}, err
+	}
+	return operation, rootType, nil
+}
+
+// executeSelectionSet executes every field selected on the object, keeping the
+// order they were selected in. Returns false if a field that cannot be null
+// resolved to null, in which case the whole object must be null
+func (e *executor) executeSelectionSet(selectionSet SelectionSet, object schema.Object, source interface{}, path []interface{}) (ResultMap, bool) {
+	fields := make(map[string][]Field)
+	var responseKeys []string
+	e.collectFields(object, selectionSet, make(map[string]struct{}), fields, &responseKeys)
+
+	result := make(ResultMap, 0, len(responseKeys))
+	for _, responseKey := range responseKeys {
+		value, ok := e.executeField(object, source, fields[responseKey], appendPath(path, responseKey))
+		if !ok {
+			return nil, false
+		}
+		result = append(result, ResultField{Key: responseKey, Value: value})
+	}
+	return result, true
+}
+
+// collectFields groups the fields selected on the object by their response
+// key, following fragments whose type condition applies to the object and
+// omitting selections excluded with @skip or @include
+func (e *executor) collectFields(object schema.Object, selectionSet SelectionSet, visitedFragments map[string]struct{}, fields map[string][]Field, responseKeys *[]string) {
+	for _, selection := range selectionSet.Selections {
+		if !e.shouldInclude(selection.GetDirectives()) {
+			continue
+		}
+
+		switch selection := selection.(type) {
+		case Field:
+			responseKey := selection.Name
+			if selection.Alias != "" {
+				responseKey = selection.Alias
+			}
+
+			if _, exists := fields[responseKey]; !exists {
+				*responseKeys = append(*responseKeys, responseKey)
+			}
+			fields[responseKey] = append(fields[responseKey], selection)
+		case InlineFragment:
+			if selection.Type != "" && !e.doesFragmentTypeApply(object, selection.Type) {
+				continue
+			}
+			e.collectFields(object, selection.SelectionSet, visitedFragments, fields, responseKeys)
+		case FragmentSpread:
+			if _, visited := visitedFragments[selection.Name]; visited {
+				continue
+			}
+			visitedFragments[selection.Name] = EXISTS
+
+			fragment, err := e.document.GetFragment(selection.Name)
+			if err != nil || !e.doesFragmentTypeApply(object, fragment.Type) {
+				continue
+			}
+			e.collectFields(object, fragment.SelectionSet, visitedFragments, fields, responseKeys)
+		}
+	}
+}
+
+// shouldInclude returns false if the directives contain @skip(if: true) or
+// @include(if: false); true otherwise
+func (e *executor) shouldInclude(directives []Directive) bool {
+	for _, directive := range directives {
+		if directive.Name != "skip" && directive.Name != "include" {
+			continue
+		}
+
+		definition, _ := e.schema.GetDirective(directive.Name)
+		arguments, err := e.coerceArgumentValues(definition.Arguments, directive.Arguments)
+		if err != nil {
+			continue
+		}
+
+		if arguments["if"] == (directive.Name == "skip") {
+			return false
+		}
+	}
+	return true
+}
+
+// doesFragmentTypeApply returns true if the object is, implements, or is a
+// member of the type named by a fragment's type condition
+func (e *executor) doesFragmentTypeApply(object schema.Object, typeName string) bool {
+	switch declaration := e.schema.GetDeclaration(schema.DescribeType(typeName)).(type) {
+	case schema.Object:
+		return declaration.Name == object.Name
+	case schema.Interface:
+		return object.ImplementsInterface(declaration.Name)
+	case schema.Union:
+		for _, member := range declaration.Types {
+			if member == object.Name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// executeField resolves and completes the value of a field on the object.
+// Returns false if the field resolved to null but its type is non-null
+func (e *executor) executeField(object schema.Object, source interface{}, fields []Field, path []interface{}) (interface{}, bool) {
+	field := fields[0]
+
+	if field.Name == "__typename" {
+		return object.Name, true
+	}
+
+	fieldDefinition, exists := object.Fields[field.Name]
+	if !exists {
+		if fieldDefinition, exists = e.schema.MetaField(object, field.Name); !exists {
+			return nil, true
+		}
+	}
+
+	value, err := e.resolveField(fieldDefinition, field, source)
+	if err != nil {
+		e.error(fields, path, "%s", err)
+		return nil, !fieldDefinition.Type.NonNull
+	}
+	return e.completeValue(fieldDefinition.Type, fields, value, path)
+}
+
+// resolveField coerces the field's arguments and calls the ResolveFunc of its
+// definition, or the default ResolveFunc if it was declared without one
+func (e *executor) resolveField(fieldDefinition schema.Field, field Field, source interface{}) (interface{}, error) {
+	arguments, err := e.coerceArgumentValues(fieldDefinition.Arguments, field.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	resolve := fieldDefinition.Resolve
+	if resolve == nil {
+		resolve = defaultResolveFunc(field.Name)
+	}
+
+	return resolve(schema.ResolveParams{
+		Context:   e.ctx,
+		Source:    source,
+		Arguments: arguments,
+	})
+}
+
+// completeValue converts a resolved value into a response value according to
+// the field's type. Returns false if a null must propagate to the parent field
+func (e *executor) completeValue(t schema.Type, fields []Field, value interface{}, path []interface{}) (interface{}, bool) {
+	nullableType := t
+	nullableType.NonNull = false
+
+	completed, ok := e.completeNullableValue(nullableType, fields, value, path)
+	if t.NonNull {
+		if ok && completed == nil {
+			e.error(fields, path, "Cannot return null for non-null type '%s'", t)
+			return nil, false
+		}
+		return completed, ok
+	}
+
+	// An error within a nullable field's value only nullifies the field itself
+	if !ok {
+		return nil, true
+	}
+	return completed, true
+}
+
+func (e *executor) completeNullableValue(t schema.Type, fields []Field, value interface{}, path []interface{}) (interface{}, bool) {
+	if isNil(value) {
+		return nil, true
+	}
+
+	if t.List {
+		list := reflect.ValueOf(value)
+		if list.Kind() != reflect.Slice && list.Kind() != reflect.Array {
+			e.error(fields, path, "Expected a list value for type '%s' but found %T", t, value)
+			return nil, false
+		}
+
+		completed := make([]interface{}, list.Len())
+		for i := 0; i < list.Len(); i++ {
+			item, ok := e.completeValue(*t.SubType, fields, list.Index(i).Interface(), appendPath(path, i))
+			if !ok {
+				return nil, false
+			}
+			completed[i] = item
+		}
+		return completed, true
+	}
+
+	var object schema.Object
+	switch declaration := e.schema.GetDeclaration(t).(type) {
+	case schema.Scalar:
+		serialized, err := serializeScalar(declaration, value)
+		if err != nil {
+			e.error(fields, path, "%s", err)
+			return nil, false
+		}
+		return serialized, true
+	case schema.Enum:
+		serialized, err := serializeEnum(declaration, value)
+		if err != nil {
+			e.error(fields, path, "%s", err)
+			return nil, false
+		}
+		return serialized, true
+	case schema.Object:
+		object = declaration
+	case schema.Interface:
+		resolved, err := e.resolveAbstractType(declaration.Name, declaration.ResolveType, value, func(object schema.Object) bool {
+			return object.ImplementsInterface(declaration.Name)
+		})
+		if err != nil {
+			e.error(fields, path, "%s", err)
+			return nil, false
+		}
+		object = resolved
+	case schema.Union:
+		resolved, err := e.resolveAbstractType(declaration.Name, declaration.ResolveType, value, func(object schema.Object) bool {
+			for _, member := range declaration.Types {
+				if member == object.Name {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			e.error(fields, path, "%s", err)
+			return nil, false
+		}
+		object = resolved
+	default:
+		e.error(fields, path, "Cannot complete value of unknown type '%s'", t)
+		return nil, false
+	}
+
+	// Fields with the same response key have their sub-selections merged
+	var selectionSet SelectionSet
+	for _, field := range fields {
+		selectionSet.Selections = append(selectionSet.Selections, field.SelectionSet.Selections...)
+	}
+	return e.executeSelectionSet(selectionSet, object, value, path)
+}
+
+// resolveAbstractType determines the Object type of a value resolved for an
+// Interface or Union using its ResolveTypeFunc
+func (e *executor) resolveAbstractType(typeName string, resolveType schema.ResolveTypeFunc, value interface{}, isPossibleType func(schema.Object) bool) (schema.Object, error) {
+	if resolveType == nil {
+		return schema.Object{}, fmt.Errorf("Type '%s' must be declared with a ResolveTypeFunc to be executed", typeName)
+	}
+
+	objectName := resolveType(value)
+	object, isObject := e.schema.GetDeclaration(schema.DescribeType(objectName)).(schema.Object)
+	if !isObject || !isPossibleType(object) {
+		return schema.Object{}, fmt.Errorf("Type '%s' resolved to '%s' which is not a possible type", typeName, objectName)
+	}
+	return object, nil
+}
+
+// defaultResolveFunc returns a ResolveFunc that reads the named field from its
+// Source. Map Sources are read by key, and struct Sources are read from the
+// exported field whose json tag or name (ignoring case) matches
+func defaultResolveFunc(name string) schema.ResolveFunc {
+	return func(params schema.ResolveParams) (interface{}, error) {
+		source := reflect.ValueOf(params.Source)
+		for source.Kind() == reflect.Ptr || source.Kind() == reflect.Interface {
+			if source.IsNil() {
+				return nil, nil
+			}
+			source = source.Elem()
+		}
+
+		switch source.Kind() {
+		case reflect.Map:
+			if source.Type().Key().Kind() == reflect.String {
+				if value := source.MapIndex(reflect.ValueOf(name).Convert(source.Type().Key())); value.IsValid() {
+					return value.Interface(), nil
+				}
+			}
+		case reflect.Struct:
+			for i := 0; i < source.NumField(); i++ {
+				structField := source.Type().Field(i)
+				if structField.PkgPath != "" {
+					continue
+				}
+
+				tag := strings.Split(structField.Tag.Get("json"), ",")[0]
+				if tag == name || tag == "" && strings.EqualFold(structField.Name, name) {
+					return source.Field(i).Interface(), nil
+				}
+			}
+		}
+		return nil, nil
+	}
+}
+
+func (e *executor) error(fields []Field, path []interface{}, format string, s ...interface{}) {
+	var locations []Loc
+	for _, field := range fields {
+		locations = append(locations, field.Loc)
+	}
+
+	e.errors = append(e.errors, &ExecutionError{
+		Message:   fmt.Sprintf(format, s...),
+		Locations: locations,
+		Path:      path,
+	})
+}
+
+// appendPath returns a copy of path with key appended. Paths are shared between
+// sibling fields so they must never be appended to in place
+func appendPath(path []interface{}, key interface{}) []interface{} {
+	extended := make([]interface{}, len(path)+1)
+	copy(extended, path)
+	extended[len(path)] = key
+	return extended
+}
+
+// isNil returns true if value is nil or a nil pointer, map, slice, etc
+func isNil(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Interface, reflect.Map, reflect.Ptr, reflect.Slice:
+		return v.IsNil()
+	}
+	return false
+}
@@ -0,0 +1,397 @@
+package graphql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	schema "github.com/WilsonGiese/graphql/schema"
+)
+
+// dogCommand is the Go value of a DogCommand
+type dogCommand int
+
+const (
+	sit dogCommand = iota
+	down
+	heel
+)
+
+type testDog struct {
+	Name            string
+	Nickname        string `json:"nickname"`
+	BarkVolume      int
+	FavoriteCommand dogCommand
+}
+
+type testCat struct {
+	Name string
+}
+
+var executionSchema *schema.Schema
+
+func init() {
+	dogs := []testDog{
+		{Name: "Rex", Nickname: "Rexy", BarkVolume: 10, FavoriteCommand: sit},
+		{Name: "Fido", BarkVolume: 3, FavoriteCommand: down},
+	}
+
+	executionSchema = schema.NewSchema().
+		Declare(schema.Enum{
+			Name: "DogCommand",
+			Values: []schema.EnumValue{
+				{Name: "SIT", Value: sit},
+				{Name: "DOWN", Value: down},
+				{Name: "HEEL", Value: heel, DeprecationReason: "Dogs no longer heel"},
+			},
+		}).
+		Declare(schema.Interface{
+			Name: "Pet",
+			Fields: schema.Fields(
+				schema.Field{
+					Name: "name",
+					Type: schema.NonNullStringType,
+				},
+			),
+			ResolveType: func(value interface{}) string {
+				if _, isDog := value.(testDog); isDog {
+					return "Dog"
+				}
+				return "Cat"
+			},
+		}).
+		Declare(schema.Object{
+			Name:       "Dog",
+			Implements: schema.Interfaces("Pet"),
+			Fields: schema.Fields(
+				schema.Field{
+					Name: "name",
+					Type: schema.NonNullStringType,
+				},
+				schema.Field{
+					Name:              "nickname",
+					Type:              schema.StringType,
+					DeprecationReason: "Use name",
+				},
+				schema.Field{
+					Name: "barkVolume",
+					Type: schema.IntType,
+				},
+				schema.Field{
+					Name: "favoriteCommand",
+					Type: schema.DescribeType("DogCommand"),
+				},
+				schema.Field{
+					Name: "doesKnowCommand",
+					Type: schema.NonNullBooleanType,
+					Arguments: schema.Arguments(
+						schema.Argument{
+							Name: "dogCommand",
+							Type: schema.DescribeNonNullType("DogCommand"),
+						},
+					),
+					Resolve: func(params schema.ResolveParams) (interface{}, error) {
+						return params.Arguments["dogCommand"] == sit, nil
+					},
+				},
+				schema.Field{
+					Name: "owner",
+					Type: schema.NonNullStringType,
+					Resolve: func(params schema.ResolveParams) (interface{}, error) {
+						return nil, errors.New("owner unknown")
+					},
+				},
+			),
+		}).
+		Declare(schema.Object{
+			Name:       "Cat",
+			Implements: schema.Interfaces("Pet"),
+			Fields: schema.Fields(
+				schema.Field{
+					Name: "name",
+					Type: schema.NonNullStringType,
+				},
+			),
+		}).
+		Declare(schema.Object{
+			Name: "QueryRoot",
+			Fields: schema.Fields(
+				schema.Field{
+					Name: "dog",
+					Type: schema.DescribeType("Dog"),
+					Arguments: schema.Arguments(
+						schema.Argument{
+							Name:    "index",
+							Type:    schema.IntType,
+							Default: 0,
+						},
+					),
+					Resolve: func(params schema.ResolveParams) (interface{}, error) {
+						return dogs[params.Arguments["index"].(int)], nil
+					},
+				},
+				schema.Field{
+					Name: "pets",
+					Type: schema.DescribeListType(schema.DescribeNonNullType("Pet")),
+					Resolve: func(params schema.ResolveParams) (interface{}, error) {
+						return []interface{}{dogs[0], testCat{Name: "Tom"}}, nil
+					},
+				},
+			),
+		}).
+		Declare(schema.Object{
+			Name: "SubscriptionRoot",
+			Fields: schema.Fields(
+				schema.Field{
+					Name: "dogBarked",
+					Type: schema.DescribeNonNullType("Dog"),
+					// Events are sent on the channel given as the root value
+					Resolve: func(params schema.ResolveParams) (interface{}, error) {
+						return params.Source, nil
+					},
+				},
+			),
+		}).Build()
+}
+
+type ExecuteTest struct {
+	query     string
+	variables map[string]interface{}
+	expected  string
+}
+
+var executeTests = []ExecuteTest{
+	{`{ dog { name nickname barkVolume } }`, nil,
+		`{"data":{"dog":{"name":"Rex","nickname":"Rexy","barkVolume":10}}}`},
+	{`{ second: dog(index: 1) { name nickname } }`, nil,
+		`{"data":{"second":{"name":"Fido","nickname":""}}}`},
+	{`query Q($index: Int) { dog(index: $index) { name } }`, map[string]interface{}{"index": float64(1)},
+		`{"data":{"dog":{"name":"Fido"}}}`},
+	{`{ dog { sit: doesKnowCommand(dogCommand: SIT) down: doesKnowCommand(dogCommand: DOWN) } }`, nil,
+		`{"data":{"dog":{"sit":true,"down":false}}}`},
+	{`query Q($command: DogCommand!) { dog { doesKnowCommand(dogCommand: $command) } }`, map[string]interface{}{"command": "SIT"},
+		`{"data":{"dog":{"doesKnowCommand":true}}}`},
+	{`{ first: dog { favoriteCommand } second: dog(index: 1) { favoriteCommand } }`, nil,
+		`{"data":{"first":{"favoriteCommand":"SIT"},"second":{"favoriteCommand":"DOWN"}}}`},
+	{`{ pets { __typename name ... on Dog { barkVolume } } }`, nil,
+		`{"data":{"pets":[{"__typename":"Dog","name":"Rex","barkVolume":10},{"__typename":"Cat","name":"Tom"}]}}`},
+	{`query { dog { name ...DogFields } } fragment DogFields on Dog { nickname barkVolume @skip(if: true) }`, nil,
+		`{"data":{"dog":{"name":"Rex","nickname":"Rexy"}}}`},
+	{`{ dog { name owner } }`, nil,
+		`{"data":{"dog":null},"errors":[{"message":"owner unknown","path":["dog","owner"]}]}`},
+	{`{ dog(index: 3000000000) { name } }`, nil,
+		`{"data":{"dog":null},"errors":[{"message":"Argument 'index' got invalid value: Int cannot represent 3000000000","path":["dog"]}]}`},
+	{`query Q($index: Int) { dog(index: $index) { name } }`, map[string]interface{}{"index": 1.5},
+		`{"data":null,"errors":[{"message":"Variable '$index' got invalid value: Int cannot represent 1.5"}]}`},
+	{`query Q($index: Int!) { dog(index: $index) { name } }`, nil,
+		`{"data":null,"errors":[{"message":"Variable '$index' of required type 'Int!' was not provided"}]}`},
+
+	// Response keys are written in the order they were selected
+	{`{ dog { name barkVolume } }`, nil,
+		`{"data":{"dog":{"name":"Rex","barkVolume":10}}}`},
+	{`{ dog { ... on Dog { volume: barkVolume } name alias: nickname name } }`, nil,
+		`{"data":{"dog":{"volume":10,"name":"Rex","alias":"Rexy"}}}`},
+}
+
+func TestExecute(t *testing.T) {
+	for _, test := range executeTests {
+		tokens, err := Tokenize(strings.NewReader(test.query), true)
+		if err != nil {
+			t.Fatalf("Tokenize(%q) failed: %s", test.query, err)
+		}
+
+		document, err := Parse(tokens)
+		if err != nil {
+			t.Fatalf("Parse(%q) failed: %s", test.query, err)
+		}
+
+		result, err := json.Marshal(Execute(executionSchema, &document, "", test.variables, nil))
+		if err != nil {
+			t.Fatalf("json.Marshal failed for %q: %s", test.query, err)
+		}
+
+		if string(result) != test.expected {
+			t.Errorf("Execute(%q)\n  expected: %s\n    actual: %s", test.query, test.expected, result)
+		}
+	}
+}
+
+func TestExecuteErrorLocations(t *testing.T) {
+	document := parseTestDocument(t, "{\n  dog {\n    owner\n    ... on Dog { owner }\n  }\n}")
+
+	result := Execute(executionSchema, &document, "", nil, nil)
+	if len(result.Errors) != 1 {
+		t.Fatalf("unexpected errors: %v", result.Errors)
+	}
+
+	var starts []Position
+	for _, loc := range result.Errors[0].Locations {
+		starts = append(starts, loc.Start)
+	}
+	expected := []Position{{Line: 2, Column: 4, Offset: 14}, {Line: 3, Column: 17, Offset: 37}}
+	if !reflect.DeepEqual(starts, expected) {
+		t.Errorf("expected locations %+v but found %+v", expected, starts)
+	}
+}
+
+// dateTimeScalar represents a time.Time formatted as an RFC 3339 string
+var dateTimeScalar = schema.Scalar{
+	Name:           "DateTime",
+	SpecifiedByURL: "https://tools.ietf.org/html/rfc3339",
+	Serialize: func(value interface{}) (interface{}, error) {
+		if t, isTime := value.(time.Time); isTime {
+			return t.Format(time.RFC3339), nil
+		}
+		return nil, fmt.Errorf("DateTime cannot represent %v", value)
+	},
+	ParseValue: func(value interface{}) (interface{}, error) {
+		if s, isString := value.(string); isString {
+			return time.Parse(time.RFC3339, s)
+		}
+		return nil, fmt.Errorf("DateTime cannot represent %v", value)
+	},
+	ParseLiteral: func(literal interface{}) (interface{}, error) {
+		if s, isString := literal.(string); isString {
+			return time.Parse(time.RFC3339, s)
+		}
+		return nil, fmt.Errorf("DateTime cannot represent %v", literal)
+	},
+}
+
+func TestExecuteCustomScalar(t *testing.T) {
+	s := schema.NewSchema().
+		Declare(dateTimeScalar).
+		Declare(schema.Object{
+			Name: "Query",
+			Fields: schema.Fields(
+				schema.Field{
+					Name: "nextDay",
+					Type: schema.DescribeType("DateTime"),
+					Arguments: schema.Arguments(
+						schema.Argument{
+							Name: "after",
+							Type: schema.DescribeNonNullType("DateTime"),
+						},
+					),
+					Resolve: func(params schema.ResolveParams) (interface{}, error) {
+						return params.Arguments["after"].(time.Time).AddDate(0, 0, 1), nil
+					},
+				},
+				schema.Field{
+					Name: "invalid",
+					Type: schema.DescribeType("DateTime"),
+					Resolve: func(params schema.ResolveParams) (interface{}, error) {
+						return "tomorrow", nil
+					},
+				},
+			),
+		}).Build()
+
+	tests := []ExecuteTest{
+		{`{ nextDay(after: "2020-02-28T12:00:00Z") }`, nil,
+			`{"data":{"nextDay":"2020-02-29T12:00:00Z"}}`},
+		{`query Q($after: DateTime!) { nextDay(after: $after) }`, map[string]interface{}{"after": "2020-12-31T00:00:00Z"},
+			`{"data":{"nextDay":"2021-01-01T00:00:00Z"}}`},
+		{`{ nextDay(after: 1582891200) }`, nil,
+			`{"data":{"nextDay":null},"errors":[{"message":"Argument 'after' got invalid value: DateTime cannot represent 1582891200","path":["nextDay"]}]}`},
+		{`{ invalid }`, nil,
+			`{"data":{"invalid":null},"errors":[{"message":"DateTime cannot represent tomorrow","path":["invalid"]}]}`},
+		{`{ dateTime: __type(name: "DateTime") { specifiedByURL } string: __type(name: "String") { specifiedByURL } }`, nil,
+			`{"data":{"dateTime":{"specifiedByURL":"https://tools.ietf.org/html/rfc3339"},"string":{"specifiedByURL":null}}}`},
+	}
+	for _, test := range tests {
+		document := parseTestDocument(t, test.query)
+
+		result, _ := json.Marshal(Execute(s, &document, "", test.variables, nil))
+		if string(result) != test.expected {
+			t.Errorf("Execute(%q)\n  expected: %s\n    actual: %s", test.query, test.expected, result)
+		}
+	}
+}
+
+func TestExecuteSDLSchema(t *testing.T) {
+	builder, err := schema.ParseSDL(strings.NewReader(`
+interface Pet { name: String! }
+type Dog implements Pet { name: String! barkVolume: Int }
+type Cat implements Pet { name: String! }
+type Query { pets: [Pet] }
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := builder.
+		Resolve("Query", "pets", func(schema.ResolveParams) (interface{}, error) {
+			return []interface{}{
+				map[string]interface{}{"name": "Rex", "barkVolume": 11},
+				map[string]interface{}{"name": "Tom"},
+			}, nil
+		}).
+		ResolveType("Pet", func(value interface{}) string {
+			if _, barks := value.(map[string]interface{})["barkVolume"]; barks {
+				return "Dog"
+			}
+			return "Cat"
+		}).
+		BuildE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	document := parseTestDocument(t, `{ pets { __typename name ... on Dog { barkVolume } } }`)
+	expected := `{"data":{"pets":[{"__typename":"Dog","name":"Rex","barkVolume":11},{"__typename":"Cat","name":"Tom"}]}}`
+
+	result, _ := json.Marshal(Execute(s, &document, "", nil, nil))
+	if string(result) != expected {
+		t.Errorf("expected: %s\n  actual: %s", expected, result)
+	}
+}
+
+func TestExecuteInputFieldDefaults(t *testing.T) {
+	s := schema.NewSchema().
+		Declare(schema.Input{
+			Name: "Page",
+			Fields: schema.Fields(
+				schema.Field{Name: "limit", Type: schema.IntType, Default: 10},
+				schema.Field{Name: "offset", Type: schema.IntType},
+			),
+		}).
+		Declare(schema.Object{
+			Name: "Query",
+			Fields: schema.Fields(
+				schema.Field{
+					Name: "limit",
+					Type: schema.IntType,
+					Arguments: schema.Arguments(
+						schema.Argument{Name: "page", Type: schema.DescribeNonNullType("Page")},
+					),
+					Resolve: func(params schema.ResolveParams) (interface{}, error) {
+						return params.Arguments["page"].(map[string]interface{})["limit"], nil
+					},
+				},
+			),
+		}).Build()
+
+	tests := []ExecuteTest{
+		{`{ limit(page: {offset: 1}) }`, nil,
+			`{"data":{"limit":10}}`},
+		{`{ limit(page: {limit: 5}) }`, nil,
+			`{"data":{"limit":5}}`},
+		{`query Q($page: Page!) { limit(page: $page) }`, map[string]interface{}{"page": map[string]interface{}{"offset": 1}},
+			`{"data":{"limit":10}}`},
+		{`{ __type(name: "Page") { inputFields { name defaultValue } } }`, nil,
+			`{"data":{"__type":{"inputFields":[{"name":"limit","defaultValue":"10"},{"name":"offset","defaultValue":null}]}}}`},
+	}
+	for _, test := range tests {
+		document := parseTestDocument(t, test.query)
+
+		result, _ := json.Marshal(Execute(s, &document, "", test.variables, nil))
+		if string(result) != test.expected {
+			t.Errorf("Execute(%q)\n  expected: %s\n    actual: %s", test.query, test.expected, result)
+		}
+	}
+}
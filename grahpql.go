@@ -1,91 +1,77 @@
 package graphql
 
-import "errors"
+import (
+	"io"
 
-type Document struct {
-	Operations []Operation
-	Fragments  []Fragment
-}
-
-func (document *Document) GetFragment(name string) (Fragment, error) {
-	for _, fragment := range document.Fragments {
-		if fragment.Name == name {
-			return fragment, nil
-		}
-	}
-	return Fragment{}, errors.New("No fragment found with that name")
-}
-
-type Operation struct {
-	Type                string
-	Name                string
-	VariableDefinitions []VariableDefinition
-	Directives          []Directive
-	SelectionSet        SelectionSet
-}
-
-type VariableDefinition struct {
-	Name    string
-	Type    Type
-	Default Value
-}
-
-type Value struct {
-	Type  Type
-	Value interface{}
-}
+	"github.com/WilsonGiese/graphql/language"
+	schema "github.com/WilsonGiese/graphql/schema"
+)
 
-type Type struct {
-	Type    string
-	NonNull bool
-	List    bool
-	SubType *Type
-}
-
-type Object struct {
-}
-
-type Directive struct {
-	Name      string
-	Arguments map[string]Value
-}
+// The Document AST, the Lexer, and the Parser are defined by the language
+// package, which the schema package also uses to parse schema definitions.
+// They are aliased here so documents can be parsed, validated, and executed
+// with this package alone
+type (
+	Document           = language.Document
+	Position           = language.Position
+	LineComment        = language.LineComment
+	Comments           = language.Comments
+	Loc                = language.Loc
+	Operation          = language.Operation
+	VariableDefinition = language.VariableDefinition
+	Value              = language.Value
+	IntValue           = language.IntValue
+	FloatValue         = language.FloatValue
+	StringValue        = language.StringValue
+	BooleanValue       = language.BooleanValue
+	NullValue          = language.NullValue
+	EnumValue          = language.EnumValue
+	Variable           = language.Variable
+	ListValue          = language.ListValue
+	ObjectValue        = language.ObjectValue
+	ObjectField        = language.ObjectField
+	Type               = language.Type
+	Directive          = language.Directive
+	Argument           = language.Argument
+	SelectionSet       = language.SelectionSet
+	Selection          = language.Selection
+	Fragment           = language.Fragment
+	InlineFragment     = language.InlineFragment
+	FragmentSpread     = language.FragmentSpread
+	Field              = language.Field
+	Token              = language.Token
+	ParseError         = language.ParseError
+)
 
-type SelectionSet struct {
-	Fields          []Field
-	InlineFragments []InlineFragment
-	FragmentSpreads []FragmentSpread
+// Tokenize converts a GraphQL document from an io.Reader into a list of Tokens.
+// Returns a *ParseError if the document contains text that cannot be tokenized
+func Tokenize(r io.Reader, ignoreWhitespace bool) ([]Token, error) {
+	return language.Tokenize(r, ignoreWhitespace)
 }
 
-// IsEmpty returns true if the SelectionSet contains no field selections
-// or fragments; false otherwise
-func (selectionSet SelectionSet) IsEmpty() bool {
-	return len(selectionSet.Fields) == 0 &&
-		len(selectionSet.FragmentSpreads) == 0 &&
-		len(selectionSet.InlineFragments) == 0
+// Parse parses a Document from a list of Tokens. Returns a *ParseError if the
+// Tokens do not form a valid Document
+func Parse(tokens []Token) (Document, error) {
+	return language.Parse(tokens)
 }
 
-type Fragment struct {
-	Name         string
-	Type         string
-	Directives   []Directive
-	SelectionSet SelectionSet
+// ParseReader parses a Document read from an io.Reader. Returns an error if
+// the document cannot be read, or a *ParseError if it cannot be tokenized or
+// is not a valid Document
+func ParseReader(r io.Reader) (Document, error) {
+	return language.ParseReader(r)
 }
 
-type InlineFragment struct {
-	Type         string
-	Directives   []Directive
-	SelectionSet SelectionSet
-}
-
-type FragmentSpread struct {
-	Name       string
-	Directives []Directive
-}
-
-type Field struct {
-	Name         string
-	Alias        string
-	Arguments    map[string]Value
-	Directives   []Directive
-	SelectionSet SelectionSet
+// operationRootType returns the Schema's root Object type for the type of the Operation.
+// Returns false if the Schema does not support the Operation's type
+func operationRootType(operation Operation, s *schema.Schema) (schema.Object, bool) {
+	switch operation.Type {
+	case "", "query": // An empty type indicates query short-hand syntax
+		return s.QueryType()
+	case "mutation":
+		return s.MutationType()
+	case "subscription":
+		return s.SubscriptionType()
+	}
+	return schema.Object{}, false
 }
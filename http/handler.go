@@ -0,0 +1,334 @@
+// Package http serves GraphQL requests over HTTP
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	graphql "github.com/WilsonGiese/graphql"
+	schema "github.com/WilsonGiese/graphql/schema"
+)
+
+// Media types of GraphQL requests and responses
+const (
+	mediaTypeJSON            = "application/json"
+	mediaTypeGraphQL         = "application/graphql"
+	mediaTypeGraphQLResponse = "application/graphql-response+json"
+)
+
+// DefaultMaxBodyBytes is the size limit of request bodies used by a Handler
+// whose MaxBodyBytes is not set
+const DefaultMaxBodyBytes = 1 << 20
+
+// Handler is an http.Handler that executes GraphQL requests against a Schema.
+// Requests may be sent with GET, giving the request in the query string, or
+// with POST, giving it in an application/json or application/graphql body.
+// Responses are written as application/graphql-response+json or
+// application/json depending on the request's Accept header
+type Handler struct {
+	Schema    *schema.Schema
+	RootValue interface{}    // Source given to the resolvers of the root types' Fields
+	Rules     []graphql.Rule // Rules requests are validated with; graphql.SpecRules if empty
+
+	// MaxBodyBytes limits the size of request bodies. Larger requests are
+	// answered with 413 Request Entity Too Large. DefaultMaxBodyBytes is used
+	// if it is not positive
+	MaxBodyBytes int64
+}
+
+// NewHandler returns a Handler executing requests against the Schema
+func NewHandler(s *schema.Schema) *Handler {
+	return &Handler{Schema: s}
+}
+
+// request is a GraphQL request decoded from an HTTP request
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// requestError describes the errors that prevent a request from being
+// executed, and the status code they are reported with
+type requestError struct {
+	status int
+	errors []responseError
+}
+
+func newRequestError(status int, format string, s ...interface{}) *requestError {
+	return &requestError{
+		status: status,
+		errors: []responseError{{Message: fmt.Sprintf(format, s...)}},
+	}
+}
+
+// responseError is an error written in the errors of a response
+type responseError struct {
+	Message   string        `json:"message"`
+	Locations []location    `json:"locations,omitempty"`
+	Path      []interface{} `json:"path,omitempty"`
+}
+
+// response is the body of the response to an executed request
+type response struct {
+	Data   graphql.ResultMap `json:"data"`
+	Errors []responseError   `json:"errors,omitempty"`
+}
+
+func newResponse(result *graphql.Result) response {
+	resp := response{Data: result.Data}
+	for _, executionErr := range result.Errors {
+		responseErr := responseError{Message: executionErr.Message, Path: executionErr.Path}
+		for _, loc := range executionErr.Locations {
+			responseErr.Locations = append(responseErr.Locations, newLocation(loc))
+		}
+		resp.Errors = append(resp.Errors, responseErr)
+	}
+	return resp
+}
+
+// location is a position in a request's query. Lines and columns are counted
+// from one
+type location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+func newLocation(loc graphql.Loc) location {
+	return location{Line: loc.Start.Line + 1, Column: loc.Start.Column + 1}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	mediaType, acceptable := negotiateMediaType(r.Header.Get("Accept"))
+	if !acceptable {
+		http.Error(w, fmt.Sprintf("Accept must allow %s or %s", mediaTypeGraphQLResponse, mediaTypeJSON), http.StatusNotAcceptable)
+		return
+	}
+
+	maxBodyBytes := h.MaxBodyBytes
+	if maxBodyBytes <= 0 {
+		maxBodyBytes = DefaultMaxBodyBytes
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+
+	result, err := h.serve(r, mediaType)
+	if err != nil {
+		if err.status == http.StatusMethodNotAllowed {
+			w.Header().Set("Allow", allowedMethods(r))
+		}
+		writeRequestError(w, mediaType, err)
+		return
+	}
+
+	// A response without data is the result of an error raised before
+	// execution, such as an invalid variable value
+	status := http.StatusOK
+	if mediaType == mediaTypeGraphQLResponse && result.Data == nil && len(result.Errors) > 0 {
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, mediaType, status, newResponse(result))
+}
+
+// serve parses, validates, and executes the GraphQL request
+func (h *Handler) serve(r *http.Request, mediaType string) (*graphql.Result, *requestError) {
+	req, err := parseRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Query == "" {
+		return nil, newRequestError(http.StatusBadRequest, "A request must contain a query")
+	}
+
+	// Invalid documents are reported with 200 OK to clients that only accept
+	// application/json, as they may not understand other status codes
+	invalidStatus := http.StatusOK
+	if mediaType == mediaTypeGraphQLResponse {
+		invalidStatus = http.StatusBadRequest
+	}
+
+	document, parseErr := graphql.ParseReader(strings.NewReader(req.Query))
+	if parseErr != nil {
+		err := newRequestError(invalidStatus, "%s", parseErr)
+		if positioned, isParseErr := parseErr.(*graphql.ParseError); isParseErr {
+			err.errors[0].Locations = []location{{Line: positioned.Line + 1, Column: positioned.Column + 1}}
+		}
+		return nil, err
+	}
+
+	if validationErrs := graphql.Validate(h.Schema, &document, h.Rules...); len(validationErrs) > 0 {
+		return nil, validationError(invalidStatus, validationErrs)
+	}
+
+	// Mutations must not be executed by GET requests, which are expected to be
+	// safe to repeat
+	if operation, err := document.GetOperation(req.OperationName); err == nil && operation.Type == "mutation" && r.Method == http.MethodGet {
+		return nil, newRequestError(http.StatusMethodNotAllowed, "Mutations cannot be executed with GET requests")
+	}
+
+	return graphql.ExecuteContext(r.Context(), h.Schema, &document, req.OperationName, req.Variables, h.RootValue), nil
+}
+
+// parseRequest decodes the GraphQL request from the HTTP request's query
+// string or body
+func parseRequest(r *http.Request) (req request, err *requestError) {
+	query := r.URL.Query()
+	switch r.Method {
+	case http.MethodGet:
+		req.Query = query.Get("query")
+	case http.MethodPost:
+		contentType, _, parseErr := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if parseErr != nil {
+			return req, newRequestError(http.StatusUnsupportedMediaType, "A POST request must have a Content-Type")
+		}
+
+		body, readErr := ioutil.ReadAll(r.Body)
+		if readErr != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(readErr, &tooLarge) {
+				return req, newRequestError(http.StatusRequestEntityTooLarge, "The request body exceeds the limit of %d bytes", tooLarge.Limit)
+			}
+			return req, newRequestError(http.StatusBadRequest, "Failed to read the request body: %s", readErr)
+		}
+
+		switch contentType {
+		case mediaTypeJSON:
+			if decodeErr := json.Unmarshal(body, &req); decodeErr != nil {
+				return req, newRequestError(http.StatusBadRequest, "The request body is not a valid GraphQL request: %s", decodeErr)
+			}
+			return req, nil
+		case mediaTypeGraphQL:
+			req.Query = string(body)
+		default:
+			return req, newRequestError(http.StatusUnsupportedMediaType, "Unsupported Content-Type %s", contentType)
+		}
+	default:
+		return req, newRequestError(http.StatusMethodNotAllowed, "Method %s is not allowed", r.Method)
+	}
+
+	// GET requests and application/graphql bodies give the other parameters in
+	// the query string
+	req.OperationName = query.Get("operationName")
+	if variables := query.Get("variables"); variables != "" {
+		if decodeErr := json.Unmarshal([]byte(variables), &req.Variables); decodeErr != nil {
+			return req, newRequestError(http.StatusBadRequest, "The variables parameter is not a JSON object: %s", decodeErr)
+		}
+	}
+	return req, nil
+}
+
+func validationError(status int, validationErrs []*graphql.ValidationError) *requestError {
+	err := &requestError{status: status}
+	for _, validationErr := range validationErrs {
+		responseErr := responseError{Message: validationErr.Message}
+		for _, loc := range validationErr.Locations {
+			responseErr.Locations = append(responseErr.Locations, newLocation(loc))
+		}
+		err.errors = append(err.errors, responseErr)
+	}
+	return err
+}
+
+// allowedMethods returns the methods the request could have been made with
+func allowedMethods(r *http.Request) string {
+	if r.Method == http.MethodGet {
+		return http.MethodPost
+	}
+	return strings.Join([]string{http.MethodGet, http.MethodPost}, ", ")
+}
+
+// negotiateMediaType returns the media type of the response for an Accept
+// header. A missing Accept header accepts application/json. Each supported
+// media type is given the quality of the most specific media range matching it,
+// and the one with the highest quality is chosen. Ties go to the more specific
+// match, then to the media range listed first. Returns false if no supported
+// media type is acceptable
+func negotiateMediaType(accept string) (string, bool) {
+	if accept == "" {
+		return mediaTypeJSON, true
+	}
+
+	type mediaRange struct {
+		mediaType string
+		quality   float64
+	}
+	var ranges []mediaRange
+	for _, accepted := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(accepted))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(q, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+		ranges = append(ranges, mediaRange{mediaType, quality})
+	}
+
+	var (
+		best                          string
+		bestQuality                   float64
+		bestSpecificity, bestPosition int
+	)
+	for _, mediaType := range []string{mediaTypeJSON, mediaTypeGraphQLResponse} {
+		quality, specificity, position := 0.0, -1, 0
+		for i, r := range ranges {
+			s := mediaRangeSpecificity(r.mediaType, mediaType)
+			if s > specificity {
+				quality, specificity, position = r.quality, s, i
+			}
+		}
+		if specificity < 0 || quality == 0 {
+			continue
+		}
+
+		if best == "" || quality > bestQuality ||
+			quality == bestQuality && (specificity > bestSpecificity ||
+				specificity == bestSpecificity && position < bestPosition) {
+			best, bestQuality, bestSpecificity, bestPosition = mediaType, quality, specificity, position
+		}
+	}
+	return best, best != ""
+}
+
+// mediaRangeSpecificity returns how specifically a media range of an Accept
+// header matches a media type: 2 for the type itself, 1 for its type/* range,
+// 0 for */*. Returns -1 if the media range does not match the media type
+func mediaRangeSpecificity(mediaRange, mediaType string) int {
+	switch {
+	case mediaRange == mediaType:
+		return 2
+	case mediaRange == mediaType[:strings.Index(mediaType, "/")]+"/*":
+		return 1
+	case mediaRange == "*/*":
+		return 0
+	}
+	return -1
+}
+
+func writeRequestError(w http.ResponseWriter, mediaType string, err *requestError) {
+	writeJSON(w, mediaType, err.status, struct {
+		Errors []responseError `json:"errors"`
+	}{err.errors})
+}
+
+func writeJSON(w http.ResponseWriter, mediaType string, status int, v interface{}) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, "Failed to encode the response", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", mediaType+"; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
@@ -0,0 +1,246 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	schema "github.com/WilsonGiese/graphql/schema"
+)
+
+var handlerSchema = schema.NewSchema().
+	Declare(schema.Object{
+		Name: "Query",
+		Fields: schema.Fields(
+			schema.Field{
+				Name: "greeting",
+				Type: schema.NonNullStringType,
+				Arguments: schema.Arguments(
+					schema.Argument{
+						Name:    "name",
+						Type:    schema.StringType,
+						Default: "World",
+					},
+				),
+				Resolve: func(params schema.ResolveParams) (interface{}, error) {
+					return "Hello, " + params.Arguments["name"].(string), nil
+				},
+			},
+			schema.Field{
+				Name: "failure",
+				Type: schema.StringType,
+				Resolve: func(params schema.ResolveParams) (interface{}, error) {
+					return nil, errors.New("Failed to resolve")
+				},
+			},
+		),
+	}).
+	Declare(schema.Object{
+		Name: "Mutation",
+		Fields: schema.Fields(
+			schema.Field{
+				Name: "reset",
+				Type: schema.NonNullBooleanType,
+				Resolve: func(params schema.ResolveParams) (interface{}, error) {
+					return true, nil
+				},
+			},
+		),
+	}).Build()
+
+type HandlerTest struct {
+	method      string
+	target      string
+	contentType string
+	accept      string
+	body        string
+
+	status   int
+	expected string
+}
+
+var handlerTests = []HandlerTest{
+	// GET requests
+	{"GET", "/?query=" + url.QueryEscape(`{ greeting }`), "", "", "",
+		http.StatusOK, `{"data":{"greeting":"Hello, World"}}`},
+	{"GET", "/?query=" + url.QueryEscape(`query Q($name: String) { greeting(name: $name) }`) +
+		"&variables=" + url.QueryEscape(`{"name":"Rex"}`) + "&operationName=Q", "", "", "",
+		http.StatusOK, `{"data":{"greeting":"Hello, Rex"}}`},
+	{"GET", "/?query=" + url.QueryEscape(`mutation { reset }`), "", "", "",
+		http.StatusMethodNotAllowed, `{"errors":[{"message":"Mutations cannot be executed with GET requests"}]}`},
+	{"GET", "/", "", "", "",
+		http.StatusBadRequest, `{"errors":[{"message":"A request must contain a query"}]}`},
+
+	// POST requests
+	{"POST", "/", "application/json", "", `{"query":"{ greeting }"}`,
+		http.StatusOK, `{"data":{"greeting":"Hello, World"}}`},
+	{"POST", "/", "application/json; charset=utf-8", "",
+		`{"query":"query Q($name: String) { greeting(name: $name) }","variables":{"name":"Rex"},"operationName":"Q"}`,
+		http.StatusOK, `{"data":{"greeting":"Hello, Rex"}}`},
+	{"POST", "/", "application/json", "", `{"query":"mutation { reset }"}`,
+		http.StatusOK, `{"data":{"reset":true}}`},
+	{"POST", "/", "application/graphql", "", `{ greeting }`,
+		http.StatusOK, `{"data":{"greeting":"Hello, World"}}`},
+	{"POST", "/", "application/graphql", "", "{\n  greeting\n  failure\n}",
+		http.StatusOK, `{"data":{"greeting":"Hello, World","failure":null},"errors":[{"message":"Failed to resolve","locations":[{"line":3,"column":3}],"path":["failure"]}]}`},
+	{"POST", "/", "application/graphql", "", `{ zebra: greeting(name: "Zebra") apple: greeting(name: "Apple") }`,
+		http.StatusOK, `{"data":{"zebra":"Hello, Zebra","apple":"Hello, Apple"}}`},
+	{"POST", "/", "application/json", "", `{"query":`,
+		http.StatusBadRequest, `{"errors":[{"message":"The request body is not a valid GraphQL request: unexpected end of JSON input"}]}`},
+	{"POST", "/", "text/plain", "", `{ greeting }`,
+		http.StatusUnsupportedMediaType, `{"errors":[{"message":"Unsupported Content-Type text/plain"}]}`},
+	{"PUT", "/", "application/json", "", `{"query":"{ greeting }"}`,
+		http.StatusMethodNotAllowed, `{"errors":[{"message":"Method PUT is not allowed"}]}`},
+
+	// Invalid documents are only reported with 400 to clients accepting
+	// application/graphql-response+json
+	{"POST", "/", "application/graphql", "application/json", "{ greeting",
+		http.StatusOK, `{"errors":[{"message":"Expected ClosedBrace but found EOF","locations":[{"line":1,"column":11}]}]}`},
+	{"POST", "/", "application/graphql", "application/graphql-response+json", "{ greeting",
+		http.StatusBadRequest, `{"errors":[{"message":"Expected ClosedBrace but found EOF","locations":[{"line":1,"column":11}]}]}`},
+	{"POST", "/", "application/graphql", "application/graphql-response+json", "{\n  greeting(name: \"World\n}",
+		http.StatusBadRequest, `{"errors":[{"message":"invalid String: World\n","locations":[{"line":2,"column":18}]}]}`},
+	{"POST", "/", "application/graphql", "application/graphql-response+json", "{\n  greeting\n  farewell\n}",
+		http.StatusBadRequest, `{"errors":[{"message":"Field Selection error: Object type 'Query' does not contain the field 'farewell'","locations":[{"line":3,"column":3}]}]}`},
+	{"POST", "/", "application/graphql", "application/graphql-response+json, application/json;q=0.9", `{ greeting }`,
+		http.StatusOK, `{"data":{"greeting":"Hello, World"}}`},
+}
+
+func TestHandler(t *testing.T) {
+	handler := NewHandler(handlerSchema)
+	for _, test := range handlerTests {
+		r := httptest.NewRequest(test.method, test.target, strings.NewReader(test.body))
+		if test.contentType != "" {
+			r.Header.Set("Content-Type", test.contentType)
+		}
+		if test.accept != "" {
+			r.Header.Set("Accept", test.accept)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if w.Code != test.status {
+			t.Errorf("%s %s: expected status %d but found %d", test.method, test.target, test.status, w.Code)
+		}
+		if actual := w.Body.String(); actual != test.expected {
+			t.Errorf("%s %s: expected response\n%s\nbut found\n%s", test.method, test.target, test.expected, actual)
+		}
+	}
+}
+
+func TestHandlerContentType(t *testing.T) {
+	handler := NewHandler(handlerSchema)
+	for accept, expected := range map[string]string{
+		"":                                  "application/json; charset=utf-8",
+		"*/*":                               "application/json; charset=utf-8",
+		"application/json":                  "application/json; charset=utf-8",
+		"application/graphql-response+json": "application/graphql-response+json; charset=utf-8",
+	} {
+		r := httptest.NewRequest("GET", "/?query="+url.QueryEscape(`{ greeting }`), nil)
+		r.Header.Set("Accept", accept)
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		if actual := w.Header().Get("Content-Type"); actual != expected {
+			t.Errorf("Accept %q: expected Content-Type %s but found %s", accept, expected, actual)
+		}
+	}
+}
+
+func TestNegotiateMediaType(t *testing.T) {
+	for _, test := range []struct {
+		accept     string
+		mediaType  string
+		acceptable bool
+	}{
+		{"", "application/json", true},
+		{"*/*", "application/json", true},
+		{"application/*", "application/json", true},
+		{"application/json", "application/json", true},
+		{"application/graphql-response+json", "application/graphql-response+json", true},
+		{"application/graphql-response+json, application/json", "application/graphql-response+json", true},
+		{"application/json, application/graphql-response+json", "application/json", true},
+		{"application/json;q=0.1, application/graphql-response+json", "application/graphql-response+json", true},
+		{"application/json;q=0.9, application/graphql-response+json;q=0.8", "application/json", true},
+		{"application/json;q=0.5, */*", "application/graphql-response+json", true},
+		{"application/json;q=0, */*", "application/graphql-response+json", true},
+		{"application/*;q=0.5, application/graphql-response+json;q=0.5", "application/graphql-response+json", true},
+		{"text/html, application/json;q=0.2", "application/json", true},
+		{"application/json;q=invalid, application/graphql-response+json;q=0.1", "application/graphql-response+json", true},
+		{"application/json;q=0", "", false},
+		{"application/json;q=0.0", "", false},
+		{"application/json;q=0.000, application/graphql-response+json;q=0", "", false},
+		{"*/*;q=0", "", false},
+		{"text/html", "", false},
+	} {
+		mediaType, acceptable := negotiateMediaType(test.accept)
+		if mediaType != test.mediaType || acceptable != test.acceptable {
+			t.Errorf("Accept %q: expected %q, %t but found %q, %t", test.accept, test.mediaType, test.acceptable, mediaType, acceptable)
+		}
+	}
+}
+
+func TestHandlerNotAcceptable(t *testing.T) {
+	r := httptest.NewRequest("GET", "/?query="+url.QueryEscape(`{ greeting }`), nil)
+	r.Header.Set("Accept", "text/html")
+
+	w := httptest.NewRecorder()
+	NewHandler(handlerSchema).ServeHTTP(w, r)
+
+	if w.Code != http.StatusNotAcceptable {
+		t.Errorf("Expected status %d but found %d", http.StatusNotAcceptable, w.Code)
+	}
+}
+
+func TestHandlerAllow(t *testing.T) {
+	for method, expected := range map[string]string{
+		"GET": "POST",
+		"PUT": "GET, POST",
+	} {
+		r := httptest.NewRequest(method, "/?query="+url.QueryEscape(`mutation { reset }`), nil)
+
+		w := httptest.NewRecorder()
+		NewHandler(handlerSchema).ServeHTTP(w, r)
+
+		if actual := w.Header().Get("Allow"); actual != expected {
+			t.Errorf("%s: expected Allow %s but found %s", method, expected, actual)
+		}
+	}
+}
+
+func TestHandlerMaxBodyBytes(t *testing.T) {
+	body := `{"query":"{ greeting }"}`
+	for maxBodyBytes, expected := range map[int64]int{
+		0:                    http.StatusOK,
+		int64(len(body)):     http.StatusOK,
+		int64(len(body)) - 1: http.StatusRequestEntityTooLarge,
+	} {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+
+		w := httptest.NewRecorder()
+		handler := NewHandler(handlerSchema)
+		handler.MaxBodyBytes = maxBodyBytes
+		handler.ServeHTTP(w, r)
+
+		if w.Code != expected {
+			t.Errorf("MaxBodyBytes %d: expected status %d but found %d: %s", maxBodyBytes, expected, w.Code, w.Body)
+		}
+	}
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader(`{"query":"`+strings.Repeat(" ", DefaultMaxBodyBytes)+`{ greeting }"}`))
+	r.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	NewHandler(handlerSchema).ServeHTTP(w, r)
+
+	expected := `{"errors":[{"message":"The request body exceeds the limit of 1048576 bytes"}]}`
+	if w.Code != http.StatusRequestEntityTooLarge || w.Body.String() != expected {
+		t.Errorf("expected status %d and response %s but found %d and %s", http.StatusRequestEntityTooLarge, expected, w.Code, w.Body)
+	}
+}
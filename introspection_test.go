@@ -0,0 +1,161 @@
+package graphql
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+var introspectionTests = []ExecuteTest{
+	{`{ __typename dog { __typename } }`, nil,
+		`{"data":{"__typename":"QueryRoot","dog":{"__typename":"Dog"}}}`},
+	{`{ __schema { queryType { name } mutationType { name } subscriptionType { name } directives { name } } }`, nil,
+		`{"data":{"__schema":{"queryType":{"name":"QueryRoot"},"mutationType":null,"subscriptionType":{"name":"SubscriptionRoot"},"directives":[{"name":"deprecated"},{"name":"include"},{"name":"skip"},{"name":"specifiedBy"}]}}}`},
+	{`{ __schema { directives { name locations isRepeatable args { name defaultValue } } } }`, nil,
+		`{"data":{"__schema":{"directives":[` +
+			`{"name":"deprecated","locations":["FIELD_DEFINITION","ARGUMENT_DEFINITION","INPUT_FIELD_DEFINITION","ENUM_VALUE"],"isRepeatable":false,"args":[{"name":"reason","defaultValue":"\"No longer supported\""}]},` +
+			`{"name":"include","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"isRepeatable":false,"args":[{"name":"if","defaultValue":null}]},` +
+			`{"name":"skip","locations":["FIELD","FRAGMENT_SPREAD","INLINE_FRAGMENT"],"isRepeatable":false,"args":[{"name":"if","defaultValue":null}]},` +
+			`{"name":"specifiedBy","locations":["SCALAR"],"isRepeatable":false,"args":[{"name":"url","defaultValue":null}]}` +
+			`]}}}`},
+	{`{ __type(name: "Dog") { kind name interfaces { name } fields { name args { name defaultValue } type { kind name ofType { kind name } } } } }`, nil,
+		`{"data":{"__type":{"kind":"OBJECT","name":"Dog","interfaces":[{"name":"Pet"}],"fields":[` +
+			`{"name":"barkVolume","args":[],"type":{"kind":"SCALAR","name":"Int","ofType":null}},` +
+			`{"name":"doesKnowCommand","args":[{"name":"dogCommand","defaultValue":null}],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"Boolean"}}},` +
+			`{"name":"favoriteCommand","args":[],"type":{"kind":"ENUM","name":"DogCommand","ofType":null}},` +
+			`{"name":"name","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String"}}},` +
+			`{"name":"owner","args":[],"type":{"kind":"NON_NULL","name":null,"ofType":{"kind":"SCALAR","name":"String"}}}` +
+			`]}}}`},
+	{`{ __type(name: "Pet") { kind fields { name } possibleTypes { name } } }`, nil,
+		`{"data":{"__type":{"kind":"INTERFACE","fields":[{"name":"name"}],"possibleTypes":[{"name":"Cat"},{"name":"Dog"}]}}}`},
+	{`{ __type(name: "Dog") { fields(includeDeprecated: true) { name isDeprecated deprecationReason } } }`, nil,
+		`{"data":{"__type":{"fields":[` +
+			`{"name":"barkVolume","isDeprecated":false,"deprecationReason":null},` +
+			`{"name":"doesKnowCommand","isDeprecated":false,"deprecationReason":null},` +
+			`{"name":"favoriteCommand","isDeprecated":false,"deprecationReason":null},` +
+			`{"name":"name","isDeprecated":false,"deprecationReason":null},` +
+			`{"name":"nickname","isDeprecated":true,"deprecationReason":"Use name"},` +
+			`{"name":"owner","isDeprecated":false,"deprecationReason":null}` +
+			`]}}}`},
+	{`{ __type(name: "DogCommand") { kind enumValues { name isDeprecated } fields { name } } }`, nil,
+		`{"data":{"__type":{"kind":"ENUM","enumValues":[{"name":"SIT","isDeprecated":false},{"name":"DOWN","isDeprecated":false}],"fields":null}}}`},
+	{`{ __type(name: "DogCommand") { enumValues(includeDeprecated: true) { name isDeprecated deprecationReason } } }`, nil,
+		`{"data":{"__type":{"enumValues":[` +
+			`{"name":"SIT","isDeprecated":false,"deprecationReason":null},` +
+			`{"name":"DOWN","isDeprecated":false,"deprecationReason":null},` +
+			`{"name":"HEEL","isDeprecated":true,"deprecationReason":"Dogs no longer heel"}` +
+			`]}}}`},
+	{`{ __type(name: "QueryRoot") { fields { name args { name defaultValue type { name } } type { kind ofType { kind ofType { name } } } } } }`, nil,
+		`{"data":{"__type":{"fields":[` +
+			`{"name":"dog","args":[{"name":"index","defaultValue":"0","type":{"name":"Int"}}],"type":{"kind":"OBJECT","ofType":null}},` +
+			`{"name":"pets","args":[],"type":{"kind":"LIST","ofType":{"kind":"NON_NULL","ofType":{"name":"Pet"}}}}` +
+			`]}}}`},
+	{`{ __type(name: "Wolf") { name } }`, nil,
+		`{"data":{"__type":null}}`},
+}
+
+func TestIntrospection(t *testing.T) {
+	for _, test := range introspectionTests {
+		document := parseTestDocument(t, test.query)
+		if errors := Validate(executionSchema, &document); len(errors) > 0 {
+			t.Errorf("Validate(%q) failed: %v", test.query, errors)
+			continue
+		}
+
+		result, _ := json.Marshal(Execute(executionSchema, &document, "", test.variables, nil))
+		if string(result) != test.expected {
+			t.Errorf("Execute(%q)\n  expected: %s\n    actual: %s", test.query, test.expected, result)
+		}
+	}
+}
+
+// The introspection query sent by GraphiQL and other tools
+const standardIntrospectionQuery = `
+query IntrospectionQuery {
+  __schema {
+    queryType { name }
+    mutationType { name }
+    subscriptionType { name }
+    types { ...FullType }
+    directives {
+      name
+      description
+      locations
+      args { ...InputValue }
+    }
+  }
+}
+
+fragment FullType on __Type {
+  kind
+  name
+  description
+  fields(includeDeprecated: true) {
+    name
+    description
+    args { ...InputValue }
+    type { ...TypeRef }
+    isDeprecated
+    deprecationReason
+  }
+  inputFields { ...InputValue }
+  interfaces { ...TypeRef }
+  enumValues(includeDeprecated: true) {
+    name
+    description
+    isDeprecated
+    deprecationReason
+  }
+  possibleTypes { ...TypeRef }
+}
+
+fragment InputValue on __InputValue {
+  name
+  description
+  type { ...TypeRef }
+  defaultValue
+}
+
+fragment TypeRef on __Type {
+  kind
+  name
+  ofType {
+    kind
+    name
+    ofType {
+      kind
+      name
+      ofType {
+        kind
+        name
+      }
+    }
+  }
+}
+`
+
+func TestStandardIntrospectionQuery(t *testing.T) {
+	document := parseTestDocument(t, standardIntrospectionQuery)
+	if errors := Validate(executionSchema, &document); len(errors) > 0 {
+		t.Fatalf("Validate failed: %v", errors)
+	}
+
+	result := Execute(executionSchema, &document, "", nil, nil)
+	if len(result.Errors) > 0 {
+		t.Fatalf("Execute failed: %v", result.Errors)
+	}
+
+	schemaValue, _ := result.Data.Get("__schema")
+	types, _ := schemaValue.(ResultMap).Get("types")
+	var names []string
+	for _, t := range types.([]interface{}) {
+		name, _ := t.(ResultMap).Get("name")
+		names = append(names, name.(string))
+	}
+
+	expected := "Boolean Cat Dog DogCommand Float ID Int Pet QueryRoot String SubscriptionRoot " +
+		"__Directive __DirectiveLocation __EnumValue __Field __InputValue __Schema __Type __TypeKind"
+	if actual := strings.Join(names, " "); actual != expected {
+		t.Errorf("unexpected types\n  expected: %s\n    actual: %s", expected, actual)
+	}
+}
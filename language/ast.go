@@ -0,0 +1,339 @@
+// Package language lexes and parses GraphQL documents
+package language
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Document struct {
+	Operations []Operation
+	Fragments  []Fragment
+	Comments   []LineComment // Every comment in the Document; only retained if the Parser's RetainComments is set
+	Loc        Loc
+}
+
+// Position is a position in the text of a Document. Lines and columns are
+// counted from zero, and Offset is counted in bytes
+type Position struct {
+	Line   int
+	Column int
+	Offset int
+}
+
+// LineComment is a comment in the text of a Document. Value is the text following
+// the # up to the end of the line
+type LineComment struct {
+	Value string
+	Loc   Loc
+}
+
+// Comments are the comments attached to a node when a Document is parsed with
+// RetainComments. Leading comments are on the lines before the node, and
+// trailing comments follow the node on the line it ends on, or on the lines
+// before the end of the block that contains it
+type Comments struct {
+	Leading  []LineComment
+	Trailing []LineComment
+}
+
+// Loc is the location of a node in the text of a Document, from the first rune
+// of the node to its last rune
+type Loc struct {
+	Start Position
+	End   Position
+}
+
+func (document *Document) GetFragment(name string) (Fragment, error) {
+	for _, fragment := range document.Fragments {
+		if fragment.Name == name {
+			return fragment, nil
+		}
+	}
+	return Fragment{}, errors.New("No fragment found with that name")
+}
+
+// GetOperation returns the Operation with the given name. If name is empty the
+// Document must contain exactly one Operation, which is returned
+func (document *Document) GetOperation(name string) (Operation, error) {
+	if name == "" {
+		if len(document.Operations) != 1 {
+			return Operation{}, errors.New("An operation name is required when a document does not contain exactly one operation")
+		}
+		return document.Operations[0], nil
+	}
+
+	for _, operation := range document.Operations {
+		if operation.Name == name {
+			return operation, nil
+		}
+	}
+	return Operation{}, errors.New("No operation found with that name")
+}
+
+type Operation struct {
+	Type                string
+	Name                string
+	VariableDefinitions []VariableDefinition
+	Directives          []Directive
+	SelectionSet        SelectionSet
+	Comments            Comments
+	Loc                 Loc
+}
+
+type VariableDefinition struct {
+	Name       string
+	Type       Type
+	Default    Value
+	Directives []Directive
+	Comments   Comments
+	Loc        Loc
+}
+
+// Value is a value given in a Document: a literal, a Variable, or a list or
+// object of Values
+type Value interface {
+	// String returns the Value as it would be written in a Document
+	String() string
+	// GetLoc returns the location of the Value in the Document
+	GetLoc() Loc
+}
+
+type IntValue struct {
+	Value int64
+	Loc   Loc
+}
+
+type FloatValue struct {
+	Value float64
+	Loc   Loc
+}
+
+type StringValue struct {
+	Value string
+	Loc   Loc
+}
+
+type BooleanValue struct {
+	Value bool
+	Loc   Loc
+}
+
+type NullValue struct {
+	Loc Loc
+}
+
+type EnumValue struct {
+	Value string
+	Loc   Loc
+}
+
+type Variable struct {
+	Name string
+	Loc  Loc
+}
+
+type ListValue struct {
+	Values []Value
+	Loc    Loc
+}
+
+type ObjectValue struct {
+	Fields map[string]ObjectField
+	Loc    Loc
+}
+
+// ObjectField is a field of an ObjectValue. Loc spans the field's name and
+// value
+type ObjectField struct {
+	Name  string
+	Value Value
+	Loc   Loc
+}
+
+func (v IntValue) GetLoc() Loc     { return v.Loc }
+func (v FloatValue) GetLoc() Loc   { return v.Loc }
+func (v StringValue) GetLoc() Loc  { return v.Loc }
+func (v BooleanValue) GetLoc() Loc { return v.Loc }
+func (v NullValue) GetLoc() Loc    { return v.Loc }
+func (v EnumValue) GetLoc() Loc    { return v.Loc }
+func (v Variable) GetLoc() Loc     { return v.Loc }
+func (v ListValue) GetLoc() Loc    { return v.Loc }
+func (v ObjectValue) GetLoc() Loc  { return v.Loc }
+
+func (v IntValue) String() string {
+	return strconv.FormatInt(v.Value, 10)
+}
+
+func (v FloatValue) String() string {
+	return strconv.FormatFloat(v.Value, 'g', -1, 64)
+}
+
+func (v StringValue) String() string {
+	return QuoteString(v.Value)
+}
+
+// QuoteString returns a GraphQL String literal of s. Quotes, backslashes, and
+// control characters are escaped with the escape sequences GraphQL defines
+func QuoteString(s string) string {
+	var builder strings.Builder
+
+	builder.WriteRune('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			builder.WriteString(`\"`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\b':
+			builder.WriteString(`\b`)
+		case '\f':
+			builder.WriteString(`\f`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		default:
+			if r < ' ' {
+				fmt.Fprintf(&builder, `\u%04X`, r)
+			} else {
+				builder.WriteRune(r)
+			}
+		}
+	}
+	builder.WriteRune('"')
+	return builder.String()
+}
+
+func (v BooleanValue) String() string {
+	return strconv.FormatBool(v.Value)
+}
+
+func (NullValue) String() string {
+	return "null"
+}
+
+func (v EnumValue) String() string {
+	return v.Value
+}
+
+func (v Variable) String() string {
+	return "$" + v.Name
+}
+
+func (v ListValue) String() string {
+	values := make([]string, len(v.Values))
+	for i, value := range v.Values {
+		values[i] = value.String()
+	}
+	return "[" + strings.Join(values, ", ") + "]"
+}
+
+// String returns the ObjectValue with its fields in order of their names
+func (v ObjectValue) String() string {
+	var names []string
+	for name := range v.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fields := make([]string, len(names))
+	for i, name := range names {
+		fields[i] = name + ": " + v.Fields[name].Value.String()
+	}
+	return "{" + strings.Join(fields, ", ") + "}"
+}
+
+type Type struct {
+	Type    string
+	NonNull bool
+	List    bool
+	SubType *Type
+	Loc     Loc
+}
+
+type Object struct {
+}
+
+type Directive struct {
+	Name      string
+	Arguments map[string]Argument
+	Loc       Loc
+}
+
+// Argument is an argument given to a Field or Directive. Loc spans the
+// argument's name and value
+type Argument struct {
+	Name  string
+	Value Value
+	Loc   Loc
+}
+
+// SelectionSet is a list of selections in the order they are written in the
+// Document, which is the order of the response keys of the result
+type SelectionSet struct {
+	Selections []Selection
+	Loc        Loc
+}
+
+// IsEmpty returns true if the SelectionSet contains no field selections
+// or fragments; false otherwise
+func (selectionSet SelectionSet) IsEmpty() bool {
+	return len(selectionSet.Selections) == 0
+}
+
+// Selection is a selection of a SelectionSet: a Field, an InlineFragment, or a
+// FragmentSpread
+type Selection interface {
+	// GetDirectives returns the directives the Selection is annotated with
+	GetDirectives() []Directive
+	// GetLoc returns the location of the Selection in the Document
+	GetLoc() Loc
+}
+
+type Fragment struct {
+	Name         string
+	Type         string
+	Directives   []Directive
+	SelectionSet SelectionSet
+	Comments     Comments
+	Loc          Loc
+}
+
+type InlineFragment struct {
+	Type         string
+	Directives   []Directive
+	SelectionSet SelectionSet
+	Comments     Comments
+	Loc          Loc
+}
+
+type FragmentSpread struct {
+	Name       string
+	Directives []Directive
+	Comments   Comments
+	Loc        Loc
+}
+
+type Field struct {
+	Name         string
+	Alias        string
+	Arguments    map[string]Argument
+	Directives   []Directive
+	SelectionSet SelectionSet
+	Comments     Comments
+	Loc          Loc
+}
+
+func (s Field) GetDirectives() []Directive          { return s.Directives }
+func (s InlineFragment) GetDirectives() []Directive { return s.Directives }
+func (s FragmentSpread) GetDirectives() []Directive { return s.Directives }
+
+func (s Field) GetLoc() Loc          { return s.Loc }
+func (s InlineFragment) GetLoc() Loc { return s.Loc }
+func (s FragmentSpread) GetLoc() Loc { return s.Loc }
@@ -1,4 +1,4 @@
-package graphql
+package language
 
 import (
 	"bufio"
@@ -7,35 +7,91 @@ import (
 	"fmt"
 	"io"
 	"strconv"
+	"strings"
 )
 
 // Tokenize tokenizes GraphQL documents from an io.Reader and returns
 // a list of Tokens corressponding to the text. Returns an error if one occurs
-// during the reading of runes from the Reader, or if there are invalid tokens
-// in the text according to the GraphQL language specifcation
+// during the reading of runes from the Reader, or a *ParseError if there are
+// invalid tokens in the text according to the GraphQL language specifcation
 func Tokenize(r io.Reader, ignoreWhitespace bool) ([]Token, error) {
 	var tokens []Token
-	lexer := lexer{reader: bufio.NewReader(r)}
+	lexer := NewLexer(r, ignoreWhitespace)
 
 	for {
-		token, err := lexer.nextToken()
+		token, err := lexer.Next()
 
 		if err != nil {
 			return tokens, err
 		}
+		tokens = append(tokens, token)
 
-		// Ignore Whitespace
-		if ignoreWhitespace {
-			if token.Type != Whitespace && token.Type != LineTerminator {
-				tokens = append(tokens, token)
+		if token.Type == EOF {
+			return tokens, nil
+		}
+	}
+}
+
+// Lexer reads Tokens from an io.Reader one at a time, so a document never has
+// to be held in memory as a whole. Once the end of the document has been
+// reached every call to Next returns an EOF Token, and once an error has
+// occurred every call returns that error
+type Lexer struct {
+	lexer            lexer
+	ignoreWhitespace bool
+	peeked           *Token // Token returned by Peek that Next has not returned yet
+	err              error
+}
+
+// NewLexer returns a Lexer reading Tokens from r. If ignoreWhitespace is true
+// Whitespace and LineTerminator Tokens are skipped
+func NewLexer(r io.Reader, ignoreWhitespace bool) *Lexer {
+	return &Lexer{
+		lexer:            lexer{reader: bufio.NewReader(r)},
+		ignoreWhitespace: ignoreWhitespace,
+	}
+}
+
+// Next returns the next Token and advances the Lexer past it. Returns an error
+// if one occurs while reading from the Reader, or a *ParseError if the next
+// Token is invalid
+func (l *Lexer) Next() (Token, error) {
+	token, err := l.Peek()
+
+	// The EOF Token stays peeked so it is returned by every following call
+	if token.Type != EOF {
+		l.peeked = nil
+	}
+	return token, err
+}
+
+// Peek returns the next Token without advancing the Lexer past it
+func (l *Lexer) Peek() (Token, error) {
+	if l.err != nil {
+		return InvalidToken, l.err
+	}
+	if l.peeked != nil {
+		return *l.peeked, nil
+	}
+
+	for {
+		start := Token{Type: Invalid, Line: l.lexer.line, ColumnStart: l.lexer.column, OffsetStart: l.lexer.offset}
+		token, err := l.lexer.nextToken()
+		if err != nil {
+			// Errors of the Reader are returned as they are, while text that
+			// cannot be tokenized is reported at the start of its Token
+			if l.lexer.readErr == nil {
+				err = &ParseError{Token: start, Line: start.Line, Column: start.ColumnStart, Message: err.Error()}
 			}
-		} else {
-			tokens = append(tokens, token)
+			l.err = err
+			return InvalidToken, err
 		}
 
-		if token.Type == EOF {
-			return tokens, nil
+		if l.ignoreWhitespace && (token.Type == Whitespace || token.Type == LineTerminator) {
+			continue
 		}
+		l.peeked = &token
+		return token, nil
 	}
 }
 
@@ -45,6 +101,12 @@ type lexer struct {
 	line        int // Current reader line position
 	column      int // Current reader column position
 	savedColumn int // Previous reader column position before line increment
+	offset      int // Current reader byte offset
+	runeOffset  int // Byte offset of the last rune read
+	savedOffset int // Byte offset of the rune read before the last rune
+	runeSize    int // Size in bytes of the last rune read; 0 if it was unread
+
+	readErr error // Error returned by the Reader other than io.EOF
 }
 
 // Lexer errors
@@ -54,14 +116,16 @@ var errNotEnoughMatchingRunes = errors.New("not enough matching runes to read")
 // Also returns the literal text read for strings, numbers, and duration tokens
 // since these token types can have different literal representations.
 func (l *lexer) nextToken() (Token, error) {
-	token := Token{Line: l.line, ColumnStart: l.column}
+	token := Token{Line: l.line, ColumnStart: l.column, OffsetStart: l.offset}
 
 	r, _, err := l.readRune()
 
 	if err != nil {
 		if err == io.EOF {
 			token.Type = EOF
+			token.LineEnd = token.Line
 			token.ColumnEnd = token.ColumnStart
+			token.OffsetEnd = token.OffsetStart
 			return token, nil
 		}
 		return InvalidToken, err
@@ -95,6 +159,8 @@ func (l *lexer) nextToken() (Token, error) {
 		token.Type = At
 	case r == '|':
 		token.Type = VerticalBar
+	case r == '&':
+		token.Type = Ampersand
 
 	// Spread "..."
 	case r == '.':
@@ -143,9 +209,16 @@ func (l *lexer) nextToken() (Token, error) {
 			return InvalidToken, err
 		}
 
-	// String
+	// Block String or String
 	case r == '"':
-		if value, err := l.consumeString(); err == nil {
+		if l.skip(`""`) {
+			if value, err := l.consumeBlockString(); err == nil {
+				token.Type = BlockString
+				token.Value = value
+			} else {
+				return InvalidToken, err
+			}
+		} else if value, err := l.consumeString(); err == nil {
 			token.Type = String
 			token.Value = value
 		} else {
@@ -165,8 +238,9 @@ func (l *lexer) nextToken() (Token, error) {
 
 	// Comment
 	case r == '#':
-		if _, err := l.consumeAll(IsCommentCharacter); err == nil {
+		if s, err := l.consumeAll(IsCommentCharacter); err == nil {
 			token.Type = Comment
+			token.Value = s
 		} else {
 			return InvalidToken, err
 		}
@@ -179,7 +253,9 @@ func (l *lexer) nextToken() (Token, error) {
 	default:
 		return InvalidToken, fmt.Errorf("invalid character: %c", r)
 	}
+	token.LineEnd = l.line
 	token.ColumnEnd = l.column - 1
+	token.OffsetEnd = l.runeOffset
 
 	if token.Type == LineTerminator {
 		l.incrementLine()
@@ -282,6 +358,97 @@ func (l *lexer) consumeString() (string, error) {
 	}
 }
 
+// consumeBlockString attempts to consume a full block string from the lexer's
+// Reader and returns its value. Assumes the opening triple quotation marks have
+// already been read
+func (l *lexer) consumeBlockString() (string, error) {
+	var raw bytes.Buffer
+
+	for {
+		r, _, err := l.readRune()
+		if err != nil {
+			if err == io.EOF {
+				return raw.String(), fmt.Errorf("invalid BlockString: %s", raw.String())
+			}
+			return raw.String(), err
+		}
+
+		switch {
+		// Final closing triple quotation; entire block string has been consumed
+		case r == '"' && l.skip(`""`):
+			return blockStringValue(raw.String()), nil
+		// Escaped triple quotation
+		case r == '\\' && l.skip(`"""`):
+			raw.WriteString(`"""`)
+		// Line Terminators are normalized to a new line
+		case r == '\u000D':
+			if nextr, _, err := l.peek(); err == nil {
+				if nextr == '\u000A' {
+					l.readRune()
+				}
+			} else if err != io.EOF {
+				return raw.String(), err
+			}
+			fallthrough
+		case r == '\u000A':
+			raw.WriteRune('\u000A')
+			l.incrementLine()
+		case IsCommentCharacter(r):
+			raw.WriteRune(r)
+		default:
+			return raw.String(), fmt.Errorf("invalid BlockString: %s%c", raw.String(), r)
+		}
+	}
+}
+
+// skip reads the runes of s if they are the next runes in the lexer's Reader.
+// Returns true if they were read, otherwise nothing is read
+func (l *lexer) skip(s string) bool {
+	next, err := l.reader.Peek(len(s))
+	if err != nil || string(next) != s {
+		return false
+	}
+
+	for range s {
+		l.readRune()
+	}
+	return true
+}
+
+// blockStringValue returns the value of a block string from its raw text by
+// removing the indentation common to every line after the first, and any
+// leading and trailing blank lines
+// http://facebook.github.io/graphql/June2018/#BlockStringValue()
+func blockStringValue(raw string) string {
+	lines := strings.Split(raw, "\n")
+
+	commonIndent := -1
+	for _, line := range lines[1:] {
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		if indent < len(line) && (commonIndent == -1 || indent < commonIndent) {
+			commonIndent = indent
+		}
+	}
+
+	if commonIndent > 0 {
+		for i := 1; i < len(lines); i++ {
+			if len(lines[i]) < commonIndent {
+				lines[i] = ""
+			} else {
+				lines[i] = lines[i][commonIndent:]
+			}
+		}
+	}
+
+	for len(lines) > 0 && strings.TrimLeft(lines[0], " \t") == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && strings.TrimLeft(lines[len(lines)-1], " \t") == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
 // consumeStringEscapeSequence consumes an escape character sequence or an
 // escaped unicode character sequence from the lexer's Reader and writes the
 // corressponding rune to the value buffer. Assumes the first forward Solidus
@@ -420,11 +587,30 @@ func (l *lexer) consumeNumber(first rune) (string, TokenType, error) {
 
 func (l *lexer) readRune() (rune, int, error) {
 	l.incrementColumn()
-	return l.reader.ReadRune()
+	r, size, err := l.reader.ReadRune()
+	if err != nil && err != io.EOF {
+		l.readErr = err
+	}
+
+	l.savedOffset = l.runeOffset
+	if err == nil {
+		l.runeOffset = l.offset
+	}
+	l.offset += size
+	l.runeSize = size
+
+	return r, size, err
 }
 
 func (l *lexer) unreadRune() error {
 	l.undoLastIncrement()
+
+	// A rune that failed to be read did not move the offsets
+	if l.runeSize > 0 {
+		l.offset -= l.runeSize
+		l.runeOffset = l.savedOffset
+		l.runeSize = 0
+	}
 	return l.reader.UnreadRune()
 }
 
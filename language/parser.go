@@ -0,0 +1,657 @@
+package language
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Parser for GraphQL. Tokens are pulled from the Parser's source as they are
+// needed, and at most two Tokens are buffered for lookahead
+type Parser struct {
+	// RetainComments keeps the Comments of a Document, attaching them to the
+	// Operations, Fragments, VariableDefinitions, and selections they are
+	// nearest to. Comments are skipped otherwise
+	RetainComments bool
+
+	source tokenSource
+	ahead  []Token // Tokens pulled from source but not yet taken
+	last   Token   // Last Token taken by the Parser
+
+	comments      []LineComment          // Every Comment pulled from source
+	tokenComments map[int]*tokenComments // Comments attached to Tokens by the offsets of the Tokens
+	read          *tokenComments         // Comments of the last Token pulled from source
+	pending       []LineComment          // Comments that precede the next Token pulled from source
+}
+
+// tokenComments are the Comments attached to a Token until they are claimed by
+// the node starting or ending at the Token
+type tokenComments struct {
+	token    Token
+	leading  []LineComment
+	trailing []LineComment
+}
+
+// tokenSource is a sequence of Tokens read by a Parser
+type tokenSource interface {
+	Next() (Token, error)
+}
+
+// lexerError is raised when the Parser's tokenSource returns an error, and is
+// recovered as that error. Text the Lexer cannot tokenize is reported by a
+// *ParseError, so only errors of the Lexer's Reader are not
+type lexerError struct {
+	err error
+}
+
+// ParseError is an error encountered while tokenizing or parsing a Document.
+// Line and Column are the position of the offending Token, counted from zero.
+// The Token of text that cannot be tokenized is Invalid, and only its start is
+// known
+type ParseError struct {
+	Token    Token
+	Line     int
+	Column   int
+	Expected []string // What was expected instead of Token; empty if Token was expected but invalid
+	Message  string
+}
+
+func (err *ParseError) Error() string {
+	return err.Message
+}
+
+// Parse parses a Document from a list of Tokens. Returns a *ParseError if the
+// Tokens do not form a valid Document
+func Parse(tokens []Token) (document Document, err error) {
+	p := Parser{source: &tokenSlice{tokens: tokens}}
+	return p.Parse()
+}
+
+// ParseReader parses a Document read from an io.Reader. Tokens are read as
+// the Parser needs them, so the text of the document is never held in memory
+// as a whole. Returns an error if the document cannot be read, or a
+// *ParseError if it cannot be tokenized or is not a valid Document
+func ParseReader(r io.Reader) (Document, error) {
+	return NewParser(NewLexer(r, true)).Parse()
+}
+
+// NewParser returns a Parser that pulls Tokens from the Lexer as they are
+// needed. Whitespace and LineTerminator Tokens are skipped, and so are Comment
+// Tokens unless RetainComments is set
+func NewParser(lexer *Lexer) *Parser {
+	return &Parser{source: lexer}
+}
+
+// Parse a GraphQL document (e.g. Query, Mutation, etc). Returns the error of
+// the Parser's Lexer if it fails to read a Token
+func (p *Parser) Parse() (document Document, err error) {
+	defer p.recover(&err)
+	document = p.parseDocument()
+	return
+}
+
+// recover recovers from a *ParseError raised while parsing and sets err to it,
+// or to the error of the Parser's source if it failed. Any other panic is a bug
+// in the Parser and is not recovered
+func (p *Parser) recover(err *error) {
+	if r := recover(); r != nil {
+		switch recovered := r.(type) {
+		case *ParseError:
+			*err = recovered
+		case lexerError:
+			*err = recovered.err
+		default:
+			panic(r)
+		}
+	}
+}
+
+func (p *Parser) parseDocument() (document Document) {
+	start := p.peek()
+
+	// A document must contain at least one definition
+	for {
+		token := p.peek()
+
+		// An OpenBrace starts an Operation using query short-hand syntax,
+		// otherwise every definition starts with its type
+		if token.Type == OpenBrace {
+			operation := Operation{Type: "query", SelectionSet: p.parseSelectionSet()}
+			operation.Loc = p.loc(token)
+			operation.Comments = p.nodeComments(token)
+			document.Operations = append(document.Operations, operation)
+		} else {
+			defintionType := p.accept(Name, "query", "mutation", "subscription", "fragment").Value // TODO remove accept, only used here
+
+			if defintionType == "fragment" {
+				fragment := p.parseFragment()
+				fragment.Loc = p.loc(token)
+				fragment.Comments = p.nodeComments(token)
+				document.Fragments = append(document.Fragments, fragment)
+			} else {
+				operation := p.parseOperation(defintionType)
+				operation.Loc = p.loc(token)
+				operation.Comments = p.nodeComments(token)
+				document.Operations = append(document.Operations, operation)
+			}
+		}
+
+		if p.peek().Type == EOF {
+			break
+		}
+	}
+	document.Loc = p.loc(start)
+	p.expect(EOF)
+	document.Comments = p.comments
+
+	return document
+}
+
+// OperationDefinition
+// OperationType Name(opt) VariableDefinitions(opt) Directives(opt) SelectionSet
+// SelectionSet
+func (p *Parser) parseOperation(operationType string) (operation Operation) {
+	operation.Type = operationType
+
+	if name, containsName := p.optional(Name); containsName {
+		operation.Name = name.Value
+	}
+
+	if p.peek().Type == OpenParen {
+		operation.VariableDefinitions = p.parseVariableDefinitions()
+	}
+
+	operation.Directives = p.parseDirectives()
+	operation.SelectionSet = p.parseSelectionSet()
+	return
+}
+
+func (p *Parser) parseFragment() (fragment Fragment) {
+	fragment.Name = p.expect(Name).Value
+	fragment.Type = p.parseTypeCondition()
+	fragment.Directives = p.parseDirectives()
+	fragment.SelectionSet = p.parseSelectionSet()
+	return
+}
+
+func (p *Parser) parseTypeCondition() string {
+	if token := p.peek(); token.Type == Name {
+		if token.Value != "on" {
+			p.unexpected(token, token.Value, "on")
+		}
+		p.take()
+	} else {
+		p.unexpected(token, token.Type.String(), "on")
+	}
+	return p.expect(Name).Value
+}
+
+func (p *Parser) parseVariableDefinitions() (varDefs []VariableDefinition) {
+	p.expect(OpenParen)
+	for {
+		token := p.peek()
+
+		if token.Type != Dollar {
+			break
+		}
+
+		varDefs = append(varDefs, p.parseVariableDefinition())
+	}
+	p.expect(ClosedParen)
+	return
+}
+
+func (p *Parser) parseVariableDefinition() (varDef VariableDefinition) {
+	start := p.expect(Dollar)
+	defer func() { varDef.Loc = p.loc(start) }()
+
+	varDef.Name = p.expect(Name).Value
+	p.expect(Colon)
+	varDef.Type = p.parseType()
+
+	if _, defaultGiven := p.optional(Equals); defaultGiven {
+		varDef.Default = p.parseValue()
+	}
+
+	varDef.Directives = p.parseDirectives()
+	varDef.Comments = p.nodeComments(start)
+	return
+}
+
+func (p *Parser) parseType() (t Type) {
+	start := p.peek()
+	defer func() { t.Loc = p.loc(start) }()
+
+	if p.peek().Type == OpenBracket {
+		p.expect(OpenBracket)
+		subType := p.parseType()
+		p.expect(ClosedBracket)
+
+		t.List = true
+		t.SubType = &subType
+	} else {
+		t.Type = p.expect(Name).Value
+	}
+
+	_, nonNull := p.optional(Exclamation)
+	t.NonNull = nonNull
+
+	return
+}
+
+func (p *Parser) parseValue() Value {
+	token := p.peek()
+	switch token.Type {
+	case Name:
+		p.take()
+		switch token.Value {
+		case "true", "false":
+			return BooleanValue{Value: token.Value == "true", Loc: p.loc(token)}
+		case "null":
+			return NullValue{Loc: p.loc(token)}
+		}
+		return EnumValue{Value: token.Value, Loc: p.loc(token)}
+	case String, BlockString:
+		p.take()
+		return StringValue{Value: token.Value, Loc: p.loc(token)}
+	case Integer:
+		p.take()
+		i, err := strconv.ParseInt(token.Value, 10, 64)
+		if err != nil {
+			p.invalid(token, fmt.Sprintf("Int value %s is out of range", token.Value))
+		}
+		return IntValue{Value: i, Loc: p.loc(token)}
+	case Float:
+		p.take()
+		f, err := strconv.ParseFloat(token.Value, 64)
+		if err != nil {
+			p.invalid(token, fmt.Sprintf("Float value %s is out of range", token.Value))
+		}
+		return FloatValue{Value: f, Loc: p.loc(token)}
+	case Dollar:
+		p.take()
+		return Variable{Name: p.expect(Name).Value, Loc: p.loc(token)}
+	case OpenBracket:
+		return p.parseListValue()
+	case OpenBrace:
+		return p.parseObjectValue()
+	}
+	p.unexpected(token, token.Type.String(), "Value")
+	return nil
+}
+
+func (p *Parser) parseListValue() (list ListValue) {
+	start := p.expect(OpenBracket)
+	for {
+		if p.peek().Type == ClosedBracket {
+			break
+		}
+
+		list.Values = append(list.Values, p.parseValue())
+	}
+	p.expect(ClosedBracket)
+	list.Loc = p.loc(start)
+	return
+}
+
+func (p *Parser) parseObjectValue() (object ObjectValue) {
+	object.Fields = make(map[string]ObjectField)
+
+	start := p.expect(OpenBrace)
+	for {
+		if p.peek().Type == ClosedBrace {
+			break
+		}
+
+		name := p.expect(Name)
+		p.expect(Colon)
+		value := p.parseValue()
+
+		if _, exists := object.Fields[name.Value]; exists {
+			p.invalid(name, "duplicate field name in object value")
+		}
+		object.Fields[name.Value] = ObjectField{Name: name.Value, Value: value, Loc: p.loc(name)}
+	}
+	p.expect(ClosedBrace)
+	object.Loc = p.loc(start)
+	return
+}
+
+func (p *Parser) parseDirectives() (directives []Directive) {
+	for {
+		if p.peek().Type != At {
+			break
+		}
+
+		directives = append(directives, p.parseDirective())
+	}
+	return
+}
+
+func (p *Parser) parseDirective() (directive Directive) {
+	start := p.expect(At)
+	directive.Name = p.expect(Name).Value
+
+	if p.peek().Type == OpenParen {
+		directive.Arguments = p.parseArguments()
+	}
+	directive.Loc = p.loc(start)
+	return
+}
+
+func (p *Parser) parseArguments() map[string]Argument {
+	arguments := make(map[string]Argument)
+
+	p.expect(OpenParen)
+	for {
+		if p.peek().Type != Name {
+			break
+		}
+
+		name := p.expect(Name)
+		p.expect(Colon)
+		value := p.parseValue()
+
+		if _, exists := arguments[name.Value]; exists {
+			p.invalid(name, "duplicate argument in arguments list")
+		}
+		arguments[name.Value] = Argument{Name: name.Value, Value: value, Loc: p.loc(name)}
+	}
+	p.expect(ClosedParen)
+	return arguments
+}
+
+func (p *Parser) parseSelectionSet() (selectionSet SelectionSet) {
+	start := p.expect(OpenBrace)
+	for {
+		token := p.peek()
+
+		if token.Type != Name && token.Type != Spread {
+			break
+		}
+
+		// Spread indicates a Fragment Spread or an Inline Fragment
+		// Fragment Spread must start with a Name that is not "on".
+		// Inline Fragment optionally starts with a type condition (on Name),
+		// followed optionally by directives, followed finally by a selection set
+		if token.Type == Spread {
+			lookahead := p.lookahead(1)
+			if lookahead.Type == Name {
+				if lookahead.Value == "on" {
+					selectionSet.Selections = append(selectionSet.Selections, p.parseInlineFragment())
+				} else {
+					selectionSet.Selections = append(selectionSet.Selections, p.parseFragmentSpread())
+				}
+			} else if lookahead.Type == At || lookahead.Type == OpenBrace {
+				selectionSet.Selections = append(selectionSet.Selections, p.parseInlineFragment())
+			} else {
+				p.unexpected(lookahead, lookahead.Type.String(), "fragment spread or inline fragment")
+			}
+		} else {
+			selectionSet.Selections = append(selectionSet.Selections, p.parseField())
+		}
+	}
+	p.expect(ClosedBrace)
+	selectionSet.Loc = p.loc(start)
+	return
+}
+
+func (p *Parser) parseFragmentSpread() (fragmentSpread FragmentSpread) {
+	start := p.expect(Spread)
+	fragmentSpread.Name = p.expect(Name).Value
+	fragmentSpread.Directives = p.parseDirectives()
+	fragmentSpread.Loc = p.loc(start)
+	fragmentSpread.Comments = p.nodeComments(start)
+
+	return
+}
+
+func (p *Parser) parseInlineFragment() (inlineFragment InlineFragment) {
+	start := p.expect(Spread)
+
+	// The type condition of an inline fragment is optional
+	if token := p.peek(); token.Type == Name && token.Value == "on" {
+		inlineFragment.Type = p.parseTypeCondition()
+	}
+	inlineFragment.Directives = p.parseDirectives()
+	inlineFragment.SelectionSet = p.parseSelectionSet()
+	inlineFragment.Loc = p.loc(start)
+	inlineFragment.Comments = p.nodeComments(start)
+	return
+}
+
+func (p *Parser) parseField() (field Field) {
+	start := p.expect(Name)
+	field.Name = start.Value
+	defer func() { field.Loc = p.loc(start) }()
+
+	if _, aliased := p.optional(Colon); aliased {
+		field.Alias = field.Name
+		field.Name = p.expect(Name).Value
+	}
+
+	if p.peek().Type == OpenParen {
+		field.Arguments = p.parseArguments()
+	}
+
+	if p.peek().Type == At {
+		field.Directives = p.parseDirectives()
+	}
+
+	if p.peek().Type == OpenBrace {
+		field.SelectionSet = p.parseSelectionSet()
+	}
+	field.Comments = p.nodeComments(start)
+	return
+}
+
+func (p *Parser) accept(t TokenType, values ...string) Token {
+	token := p.peek()
+
+	if token.Type == t {
+		for _, value := range values {
+			if value == token.Value {
+				p.take()
+				return token
+			}
+		}
+		p.unexpected(token, token.Value, values...)
+	}
+	p.unexpected(token, token.Type.String(), values...)
+	panic("unreachable")
+}
+
+func (p *Parser) peek() Token {
+	return p.lookahead(0)
+}
+
+// lookahead returns the Token distance Tokens ahead of the current Token,
+// pulling Tokens from the Parser's source until it is available. The grammar
+// never needs to look further ahead than the Token after the current one
+func (p *Parser) lookahead(distance int) Token {
+	for len(p.ahead) <= distance {
+		token, err := p.source.Next()
+		if err != nil {
+			panic(lexerError{err})
+		}
+
+		switch token.Type {
+		case Whitespace, LineTerminator:
+		case Comment:
+			if p.RetainComments {
+				p.readComment(token)
+			}
+		default:
+			if p.RetainComments {
+				p.readCommented(token)
+			}
+			p.ahead = append(p.ahead, token)
+		}
+	}
+	return p.ahead[distance]
+}
+
+// readComment keeps a comment pulled from source. A Comment on the line of the
+// Token before it trails that Token, unless the Token opens a block, in which
+// case the Comment leads the next Token like Comments on lines of their own
+func (p *Parser) readComment(token Token) {
+	if p.tokenComments == nil {
+		p.tokenComments = make(map[int]*tokenComments)
+	}
+
+	comment := LineComment{
+		Value: token.Value,
+		Loc: Loc{
+			Start: Position{Line: token.Line, Column: token.ColumnStart, Offset: token.OffsetStart},
+			End:   Position{Line: token.Line, Column: token.ColumnEnd, Offset: token.OffsetEnd},
+		},
+	}
+	p.comments = append(p.comments, comment)
+
+	if p.read != nil && p.read.token.Line == token.Line {
+		switch p.read.token.Type {
+		case OpenBrace, OpenParen, OpenBracket:
+		default:
+			p.read.trailing = append(p.read.trailing, comment)
+			p.tokenComments[p.read.token.OffsetStart] = p.read
+			return
+		}
+	}
+	p.pending = append(p.pending, comment)
+}
+
+// readCommented attaches the pending Comments to a Token pulled from source.
+// No node starts at a Token closing a block or at the end of the Document, so
+// the Comments before them trail the Token before them instead
+func (p *Parser) readCommented(token Token) {
+	comments := &tokenComments{token: token}
+	if len(p.pending) > 0 {
+		switch token.Type {
+		case ClosedBrace, ClosedParen, ClosedBracket, EOF:
+			if p.read != nil {
+				p.read.trailing = append(p.read.trailing, p.pending...)
+				p.tokenComments[p.read.token.OffsetStart] = p.read
+			}
+		default:
+			comments.leading = p.pending
+			p.tokenComments[token.OffsetStart] = comments
+		}
+		p.pending = nil
+	}
+	p.read = comments
+}
+
+// nodeComments claims the Comments of a node: the leading Comments of its start
+// Token and the trailing Comments of the last Token taken. The next Token is
+// pulled first so that every Comment trailing the node has been read
+func (p *Parser) nodeComments(start Token) (comments Comments) {
+	if !p.RetainComments {
+		return
+	}
+	p.peek()
+
+	if leading, exists := p.tokenComments[start.OffsetStart]; exists {
+		comments.Leading = leading.leading
+		leading.leading = nil
+	}
+	if trailing, exists := p.tokenComments[p.last.OffsetStart]; exists {
+		comments.Trailing = trailing.trailing
+		trailing.trailing = nil
+	}
+	return
+}
+
+func (p *Parser) take() Token {
+	token := p.peek()
+	p.last = token
+
+	// The EOF Token is never taken, so it can be peeked again
+	if token.Type != EOF {
+		p.ahead = p.ahead[1:]
+	}
+
+	return token
+}
+
+// Expect a token of TokenType t from the Parser's Tokens. If the type of the
+// current token does not match t a panic will occur
+func (p *Parser) expect(t TokenType) Token {
+	token := p.peek()
+
+	if token.Type != t {
+		p.unexpected(token, token.Type.String(), t.String())
+	}
+	p.take()
+
+	return token
+}
+
+func (p *Parser) optional(t TokenType) (Token, bool) {
+	token := p.peek()
+
+	if token.Type == t {
+		p.take()
+		return token, true
+	}
+
+	return InvalidToken, false
+}
+
+// loc returns the location of a node from the first rune of its start Token to
+// the last rune of the last Token taken
+func (p *Parser) loc(start Token) Loc {
+	return Loc{
+		Start: Position{Line: start.Line, Column: start.ColumnStart, Offset: start.OffsetStart},
+		End:   Position{Line: p.last.LineEnd, Column: p.last.ColumnEnd, Offset: p.last.OffsetEnd},
+	}
+}
+
+// unexpected raises a *ParseError for a Token that is not one of the expected
+// tokens. actual describes the Token in the error message
+func (p *Parser) unexpected(token Token, actual string, expected ...string) {
+	panic(&ParseError{
+		Token:    token,
+		Line:     token.Line,
+		Column:   token.ColumnStart,
+		Expected: expected,
+		Message:  fmt.Sprintf("Expected %s but found %s", strings.Join(expected, " or "), actual),
+	})
+}
+
+// invalid raises a *ParseError for a Token that was expected but is invalid
+func (p *Parser) invalid(token Token, message string) {
+	panic(&ParseError{
+		Token:   token,
+		Line:    token.Line,
+		Column:  token.ColumnStart,
+		Message: "invalid: " + message,
+	})
+}
+
+// tokenSlice is a tokenSource reading from a list of Tokens. Tokens that do
+// not end with EOF are treated as if they did
+type tokenSlice struct {
+	tokens []Token
+	last   Token
+}
+
+func (s *tokenSlice) Next() (Token, error) {
+	if len(s.tokens) == 0 {
+		if s.last.Type == EOF {
+			return s.last, nil
+		}
+
+		eof := Token{Type: EOF, Line: s.last.LineEnd, LineEnd: s.last.LineEnd}
+		if s.last != (Token{}) {
+			eof.ColumnStart, eof.ColumnEnd = s.last.ColumnEnd+1, s.last.ColumnEnd+1
+			eof.OffsetStart, eof.OffsetEnd = s.last.OffsetEnd+1, s.last.OffsetEnd+1
+		}
+		return eof, nil
+	}
+
+	token := s.tokens[0]
+	s.tokens = s.tokens[1:]
+	s.last = token
+	return token, nil
+}
@@ -0,0 +1,355 @@
+package language
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParser(t *testing.T) {
+	tokens := []Token{
+		Token{Type: Name, Value: "query"},
+		Token{Type: OpenBrace},
+		Token{Type: ClosedBrace},
+		Token{Type: EOF},
+	}
+
+	_, err := Parse(tokens)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+var valueTests = []struct {
+	value    string
+	expected Value
+}{
+	{`123`, IntValue{Value: 123}},
+	{`-1.5e3`, FloatValue{Value: -1500}},
+	{`"abc"`, StringValue{Value: "abc"}},
+	{`"true"`, StringValue{Value: "true"}},
+	{"\"\"\"  block\n  string\"\"\"", StringValue{Value: "  block\nstring"}},
+	{`true`, BooleanValue{Value: true}},
+	{`false`, BooleanValue{Value: false}},
+	{`null`, NullValue{}},
+	{`SIT`, EnumValue{Value: "SIT"}},
+	{`$dog`, Variable{Name: "dog"}},
+	{`[1, "a", null]`, ListValue{Values: []Value{IntValue{Value: 1}, StringValue{Value: "a"}, NullValue{}}}},
+	{`{name: "Rex", commands: [SIT]}`, ObjectValue{Fields: map[string]ObjectField{
+		"name":     {Name: "name", Value: StringValue{Value: "Rex"}},
+		"commands": {Name: "commands", Value: ListValue{Values: []Value{EnumValue{Value: "SIT"}}}},
+	}}},
+}
+
+func TestParseValue(t *testing.T) {
+	for _, test := range valueTests {
+		tokens, err := Tokenize(strings.NewReader(`{ dog(value: `+test.value+`) }`), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		document, err := Parse(tokens)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %s", test.value, err)
+			continue
+		}
+
+		// Locations are checked by TestParseLoc
+		actual := document.Operations[0].SelectionSet.Selections[0].(Field).Arguments["value"].Value
+		if reflect.TypeOf(actual) != reflect.TypeOf(test.expected) || actual.String() != test.expected.String() {
+			t.Errorf("Parse(%q)\n  expected: %#v\n    actual: %#v", test.value, test.expected, actual)
+		}
+	}
+}
+
+var quoteStringTests = []struct {
+	s        string
+	expected string
+}{
+	{"Rex", `"Rex"`},
+	{"say \"sit\"\\", `"say \"sit\"\\"`},
+	{"\b\f\n\r\t", `"\b\f\n\r\t"`},
+	{"\x00\a\x1f", `"\u0000\u0007\u001F"`},
+	{"caf\u00e9", "\"caf\u00e9\""},
+}
+
+// Quoted strings must be valid String literals of the same value
+func TestQuoteString(t *testing.T) {
+	for _, test := range quoteStringTests {
+		actual := QuoteString(test.s)
+		if actual != test.expected {
+			t.Errorf("QuoteString(%q)\n  expected: %s\n    actual: %s", test.s, test.expected, actual)
+		}
+
+		tokens, err := Tokenize(strings.NewReader(actual), true)
+		if err != nil {
+			t.Errorf("Tokenize(%s) failed: %s", actual, err)
+			continue
+		}
+		if tokens[0].Type != String || tokens[0].Value != test.s {
+			t.Errorf("Tokenize(%s)\n  expected: %q\n    actual: %q", actual, test.s, tokens[0].Value)
+		}
+	}
+}
+
+func TestParseValueIntOutOfRange(t *testing.T) {
+	tokens, _ := Tokenize(strings.NewReader(`{ dog(value: 99999999999999999999) }`), true)
+	if _, err := Parse(tokens); err == nil || err.Error() != "invalid: Int value 99999999999999999999 is out of range" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// failingReader returns its contents followed by an error instead of io.EOF
+type failingReader struct {
+	contents *strings.Reader
+}
+
+func (r failingReader) Read(p []byte) (int, error) {
+	if r.contents.Len() == 0 {
+		return 0, errors.New("read failed")
+	}
+	return r.contents.Read(p)
+}
+
+func TestParseReader(t *testing.T) {
+	document, err := ParseReader(strings.NewReader("# Dogs\nquery Dogs { dog { name } }\nfragment F on Dog { name }"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(document.Operations) != 1 || len(document.Fragments) != 1 {
+		t.Errorf("unexpected Document: %+v", document)
+	}
+
+	if _, err := ParseReader(strings.NewReader("{ dog(name: \"Rex) }")); err == nil || err.Error() != "invalid String: Rex) }" {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	if _, err := ParseReader(failingReader{strings.NewReader("{ dog { name } }")}); err == nil || err.Error() != "read failed" {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseSelectionOrder(t *testing.T) {
+	tokens, _ := Tokenize(strings.NewReader(`{ a ...F b ... on Dog { c } d }`), true)
+	document, err := Parse(tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var actual []string
+	for _, selection := range document.Operations[0].SelectionSet.Selections {
+		switch selection := selection.(type) {
+		case Field:
+			actual = append(actual, selection.Name)
+		case InlineFragment:
+			actual = append(actual, "... on "+selection.Type)
+		case FragmentSpread:
+			actual = append(actual, "..."+selection.Name)
+		}
+	}
+
+	expected := []string{"a", "...F", "b", "... on Dog", "d"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("expected selections %q but found %q", expected, actual)
+	}
+}
+
+func TestParseLoc(t *testing.T) {
+	query := "query Dog($id: ID!) {\n  dog(id: $id) {\n    ...on Dog @include(if: true) { name }\n  }\n}"
+	tokens, _ := Tokenize(strings.NewReader(query), true)
+	document, err := Parse(tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operation := document.Operations[0]
+	dog := operation.SelectionSet.Selections[0].(Field)
+	inlineFragment := dog.SelectionSet.Selections[0].(InlineFragment)
+
+	locTests := []struct {
+		node     string
+		loc      Loc
+		expected string
+	}{
+		{"Document", document.Loc, query},
+		{"Operation", operation.Loc, query},
+		{"VariableDefinition", operation.VariableDefinitions[0].Loc, "$id: ID!"},
+		{"Type", operation.VariableDefinitions[0].Type.Loc, "ID!"},
+		{"Field", dog.Loc, "dog(id: $id) {\n    ...on Dog @include(if: true) { name }\n  }"},
+		{"Argument", dog.Arguments["id"].Loc, "id: $id"},
+		{"Variable", dog.Arguments["id"].Value.GetLoc(), "$id"},
+		{"InlineFragment", inlineFragment.Loc, "...on Dog @include(if: true) { name }"},
+		{"Directive", inlineFragment.Directives[0].Loc, "@include(if: true)"},
+		{"Argument", inlineFragment.Directives[0].Arguments["if"].Loc, "if: true"},
+		{"BooleanValue", inlineFragment.Directives[0].Arguments["if"].Value.GetLoc(), "true"},
+		{"SelectionSet", inlineFragment.SelectionSet.Loc, "{ name }"},
+	}
+	for _, test := range locTests {
+		if actual := query[test.loc.Start.Offset : test.loc.End.Offset+1]; actual != test.expected {
+			t.Errorf("%s Loc %+v\n  expected: %q\n    actual: %q", test.node, test.loc, test.expected, actual)
+		}
+	}
+
+	if start := dog.Loc.Start; start.Line != 1 || start.Column != 2 {
+		t.Errorf("unexpected Field start position: %+v", start)
+	}
+}
+
+func TestParseLocObjectField(t *testing.T) {
+	query := `{ dogs(filter: { name: "Rex", owner: { name: $owner } }) { name } }`
+	tokens, _ := Tokenize(strings.NewReader(query), true)
+	document, err := Parse(tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := document.Operations[0].SelectionSet.Selections[0].(Field).Arguments["filter"]
+	owner := filter.Value.(ObjectValue).Fields["owner"]
+	for _, test := range []struct {
+		node     string
+		loc      Loc
+		expected string
+	}{
+		{"Argument", filter.Loc, `filter: { name: "Rex", owner: { name: $owner } }`},
+		{"ObjectField", filter.Value.(ObjectValue).Fields["name"].Loc, `name: "Rex"`},
+		{"ObjectField", owner.Loc, `owner: { name: $owner }`},
+		{"ObjectField", owner.Value.(ObjectValue).Fields["name"].Loc, `name: $owner`},
+	} {
+		if actual := query[test.loc.Start.Offset : test.loc.End.Offset+1]; actual != test.expected {
+			t.Errorf("%s Loc %+v\n  expected: %q\n    actual: %q", test.node, test.loc, test.expected, actual)
+		}
+	}
+}
+
+func TestParseLocBlockString(t *testing.T) {
+	query := "{\n  dog(name: \"\"\"\n    Fido\n  \"\"\") { name }\n}"
+	tokens, _ := Tokenize(strings.NewReader(query), true)
+	document, err := Parse(tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dog := document.Operations[0].SelectionSet.Selections[0].(Field)
+	loc := dog.Arguments["name"].Value.GetLoc()
+
+	expected := Loc{
+		Start: Position{Line: 1, Column: 12, Offset: 14},
+		End:   Position{Line: 3, Column: 4, Offset: 31},
+	}
+	if loc != expected {
+		t.Errorf("unexpected BlockString Loc\n  expected: %+v\n    actual: %+v", expected, loc)
+	}
+	if actual := query[loc.Start.Offset : loc.End.Offset+1]; actual != "\"\"\"\n    Fido\n  \"\"\"" {
+		t.Errorf("unexpected BlockString text: %q", actual)
+	}
+	if end := dog.Loc.End; end.Line != 3 || end.Column != 14 {
+		t.Errorf("unexpected Field end position: %+v", end)
+	}
+}
+
+func TestParseComments(t *testing.T) {
+	query := `# Dogs query
+query Dogs(
+  # Dog ID
+  $id: ID! # Required
+) {
+  # The dog
+  dog(id: $id) { # Dog fields
+    name # Name
+    # End of dog fields
+  }
+  ... on QueryRoot { __typename } # Inline fragment
+} # End of Dogs
+# End of document`
+
+	parser := NewParser(NewLexer(strings.NewReader(query), true))
+	parser.RetainComments = true
+	document, err := parser.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operation := document.Operations[0]
+	dog := operation.SelectionSet.Selections[0].(Field)
+
+	commentTests := []struct {
+		node     string
+		comments Comments
+		leading  []string
+		trailing []string
+	}{
+		{"Operation", operation.Comments, []string{" Dogs query"}, []string{" End of Dogs", " End of document"}},
+		{"VariableDefinition", operation.VariableDefinitions[0].Comments, []string{" Dog ID"}, []string{" Required"}},
+		{"Field", dog.Comments, []string{" The dog"}, nil},
+		{"Field", dog.SelectionSet.Selections[0].(Field).Comments, []string{" Dog fields"}, []string{" Name", " End of dog fields"}},
+		{"InlineFragment", operation.SelectionSet.Selections[1].(InlineFragment).Comments, nil, []string{" Inline fragment"}},
+	}
+	for _, test := range commentTests {
+		var leading, trailing []string
+		for _, comment := range test.comments.Leading {
+			leading = append(leading, comment.Value)
+		}
+		for _, comment := range test.comments.Trailing {
+			trailing = append(trailing, comment.Value)
+		}
+
+		if !reflect.DeepEqual(leading, test.leading) || !reflect.DeepEqual(trailing, test.trailing) {
+			t.Errorf("%s comments\n  expected: %q %q\n    actual: %q %q", test.node, test.leading, test.trailing, leading, trailing)
+		}
+	}
+
+	if len(document.Comments) != 10 {
+		t.Errorf("expected 10 Document comments but found %d", len(document.Comments))
+	}
+	if loc := document.Comments[0].Loc; loc.Start.Offset != 0 || loc.End.Offset != 11 {
+		t.Errorf("unexpected comment Loc: %+v", loc)
+	}
+
+	// Comments are skipped unless they are retained
+	document, err = ParseReader(strings.NewReader(query))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if document.Comments != nil || document.Operations[0].Comments.Leading != nil {
+		t.Errorf("unexpected comments: %+v", document.Comments)
+	}
+}
+
+var parseErrorTests = []struct {
+	tokens   []Token
+	expected ParseError
+}{
+	{
+		nil,
+		ParseError{Token: Token{Type: EOF}, Expected: []string{"query", "mutation", "subscription", "fragment"}, Message: "Expected query or mutation or subscription or fragment but found EOF"},
+	},
+	{
+		[]Token{Token{Type: OpenBrace}, Token{Type: Name, Value: "dog", Line: 1, LineEnd: 1, ColumnStart: 2, ColumnEnd: 4, OffsetStart: 3, OffsetEnd: 5}},
+		ParseError{Token: Token{Type: EOF, Line: 1, LineEnd: 1, ColumnStart: 5, ColumnEnd: 5, OffsetStart: 6, OffsetEnd: 6}, Line: 1, Column: 5, Expected: []string{"ClosedBrace"}, Message: "Expected ClosedBrace but found EOF"},
+	},
+}
+
+func TestParseError(t *testing.T) {
+	for _, test := range parseErrorTests {
+		_, err := Parse(test.tokens)
+
+		parseErr, isParseErr := err.(*ParseError)
+		if !isParseErr || !reflect.DeepEqual(*parseErr, test.expected) {
+			t.Errorf("Parse(%v)\n  expected: %+v\n    actual: %+v", test.tokens, test.expected, err)
+		}
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	tokens, _ := Tokenize(strings.NewReader("{\n  dog(name: \"Rex\", name: \"Fido\")\n}"), true)
+	_, err := Parse(tokens)
+
+	parseErr, isParseErr := err.(*ParseError)
+	if !isParseErr {
+		t.Fatalf("expected a *ParseError but found %v", err)
+	}
+	if parseErr.Line != 1 || parseErr.Column != 19 || parseErr.Token.Value != "name" || parseErr.Error() != "invalid: duplicate argument in arguments list" {
+		t.Errorf("unexpected ParseError: %+v", *parseErr)
+	}
+}
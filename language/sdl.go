@@ -0,0 +1,428 @@
+package language
+
+import "io"
+
+// SchemaDocument is a document written in the GraphQL schema definition
+// language
+type SchemaDocument struct {
+	Types      []TypeDefinition
+	Directives []DirectiveDefinition
+	RootTypes  map[string]string // Root type names by operation type, as given by the schema definition
+	Loc        Loc
+}
+
+// TypeDefinition is the definition of a type in a SchemaDocument: a
+// ScalarDefinition, ObjectDefinition, InterfaceDefinition, UnionDefinition,
+// EnumDefinition, or InputDefinition
+type TypeDefinition interface {
+	// GetName returns the name of the type
+	GetName() string
+	// GetLoc returns the location of the definition in the SchemaDocument
+	GetLoc() Loc
+}
+
+type ScalarDefinition struct {
+	Description string
+	Name        string
+	Directives  []Directive
+	Loc         Loc
+}
+
+type ObjectDefinition struct {
+	Description string
+	Name        string
+	Implements  []string
+	Directives  []Directive
+	Fields      []FieldDefinition
+	Loc         Loc
+}
+
+type InterfaceDefinition struct {
+	Description string
+	Name        string
+	Directives  []Directive
+	Fields      []FieldDefinition
+	Loc         Loc
+}
+
+type UnionDefinition struct {
+	Description string
+	Name        string
+	Directives  []Directive
+	Types       []string
+	Loc         Loc
+}
+
+type EnumDefinition struct {
+	Description string
+	Name        string
+	Directives  []Directive
+	Values      []EnumValueDefinition
+	Loc         Loc
+}
+
+type EnumValueDefinition struct {
+	Description string
+	Name        string
+	Directives  []Directive
+	Loc         Loc
+}
+
+type InputDefinition struct {
+	Description string
+	Name        string
+	Directives  []Directive
+	Fields      []InputValueDefinition
+	Loc         Loc
+}
+
+type DirectiveDefinition struct {
+	Description string
+	Name        string
+	Arguments   []InputValueDefinition
+	Repeatable  bool
+	Locations   []string
+	Loc         Loc
+}
+
+type FieldDefinition struct {
+	Description string
+	Name        string
+	Arguments   []InputValueDefinition
+	Type        Type
+	Directives  []Directive
+	Loc         Loc
+}
+
+// InputValueDefinition is the definition of an argument or of a field of an
+// input type. Default is nil if no default value is given
+type InputValueDefinition struct {
+	Description string
+	Name        string
+	Type        Type
+	Default     Value
+	Directives  []Directive
+	Loc         Loc
+}
+
+func (d ScalarDefinition) GetName() string    { return d.Name }
+func (d ObjectDefinition) GetName() string    { return d.Name }
+func (d InterfaceDefinition) GetName() string { return d.Name }
+func (d UnionDefinition) GetName() string     { return d.Name }
+func (d EnumDefinition) GetName() string      { return d.Name }
+func (d InputDefinition) GetName() string     { return d.Name }
+
+func (d ScalarDefinition) GetLoc() Loc    { return d.Loc }
+func (d ObjectDefinition) GetLoc() Loc    { return d.Loc }
+func (d InterfaceDefinition) GetLoc() Loc { return d.Loc }
+func (d UnionDefinition) GetLoc() Loc     { return d.Loc }
+func (d EnumDefinition) GetLoc() Loc      { return d.Loc }
+func (d InputDefinition) GetLoc() Loc     { return d.Loc }
+
+// ParseSchemaReader parses a SchemaDocument read from an io.Reader. Comments
+// carry no meaning in a schema definition and are skipped. Returns an error if
+// the document cannot be read, or a *ParseError if it cannot be tokenized or
+// is not a valid SchemaDocument
+func ParseSchemaReader(r io.Reader) (SchemaDocument, error) {
+	return NewParser(NewLexer(r, true)).ParseSchema()
+}
+
+// ParseSchema parses a GraphQL schema definition document. Returns the error of
+// the Parser's Lexer if it fails to read a Token
+func (p *Parser) ParseSchema() (document SchemaDocument, err error) {
+	defer p.recover(&err)
+	document = p.parseSchemaDocument()
+	return
+}
+
+func (p *Parser) parseSchemaDocument() (document SchemaDocument) {
+	start := p.peek()
+
+	document.RootTypes = make(map[string]string)
+	for p.peek().Type != EOF {
+		token := p.peek()
+		description := p.parseDescription()
+
+		switch definitionType := p.accept(Name, "schema", "scalar", "type", "interface", "union", "enum", "input", "directive").Value; definitionType {
+		case "schema":
+			p.parseSchemaDefinition(document.RootTypes)
+		case "scalar":
+			scalar := p.parseScalarDefinition(description)
+			scalar.Loc = p.loc(token)
+			document.Types = append(document.Types, scalar)
+		case "type":
+			object := p.parseObjectDefinition(description)
+			object.Loc = p.loc(token)
+			document.Types = append(document.Types, object)
+		case "interface":
+			intrface := p.parseInterfaceDefinition(description)
+			intrface.Loc = p.loc(token)
+			document.Types = append(document.Types, intrface)
+		case "union":
+			union := p.parseUnionDefinition(description)
+			union.Loc = p.loc(token)
+			document.Types = append(document.Types, union)
+		case "enum":
+			enum := p.parseEnumDefinition(description)
+			enum.Loc = p.loc(token)
+			document.Types = append(document.Types, enum)
+		case "input":
+			input := p.parseInputDefinition(description)
+			input.Loc = p.loc(token)
+			document.Types = append(document.Types, input)
+		case "directive":
+			directive := p.parseDirectiveDefinition(description)
+			directive.Loc = p.loc(token)
+			document.Directives = append(document.Directives, directive)
+		}
+	}
+	p.expect(EOF)
+
+	document.Loc = p.loc(start)
+	return document
+}
+
+// Description(opt) is a String or BlockString preceding a definition
+func (p *Parser) parseDescription() string {
+	if description, described := p.optional(String); described {
+		return description.Value
+	}
+	if description, described := p.optional(BlockString); described {
+		return description.Value
+	}
+	return ""
+}
+
+// SchemaDefinition
+// schema Directives(opt) { RootOperationTypeDefinition(list) }
+func (p *Parser) parseSchemaDefinition(rootTypes map[string]string) {
+	p.parseDirectives()
+	p.expect(OpenBrace)
+	for p.peek().Type != ClosedBrace {
+		token := p.accept(Name, "query", "mutation", "subscription")
+		operationType := token.Value
+		p.expect(Colon)
+
+		if _, exists := rootTypes[operationType]; exists {
+			p.invalid(token, "duplicate "+operationType+" root type in schema definition")
+		}
+		rootTypes[operationType] = p.expect(Name).Value
+	}
+	p.expect(ClosedBrace)
+}
+
+// ScalarTypeDefinition
+// Description(opt) scalar Name Directives(opt)
+func (p *Parser) parseScalarDefinition(description string) (scalar ScalarDefinition) {
+	scalar.Description = description
+	scalar.Name = p.expect(Name).Value
+	scalar.Directives = p.parseDirectives()
+	return
+}
+
+// ObjectTypeDefinition
+// Description(opt) type Name ImplementsInterfaces(opt) Directives(opt) FieldsDefinition
+func (p *Parser) parseObjectDefinition(description string) (object ObjectDefinition) {
+	object.Description = description
+	object.Name = p.expect(Name).Value
+
+	if token := p.peek(); token.Type == Name && token.Value == "implements" {
+		p.take()
+		p.optional(Ampersand)
+		object.Implements = append(object.Implements, p.expect(Name).Value)
+
+		// Interfaces are separated by & (or by whitespace in older documents)
+		for {
+			if _, separated := p.optional(Ampersand); !separated && p.peek().Type != Name {
+				break
+			}
+			object.Implements = append(object.Implements, p.expect(Name).Value)
+		}
+	}
+
+	object.Directives = p.parseDirectives()
+	object.Fields = p.parseFieldsDefinition()
+	return
+}
+
+// InterfaceTypeDefinition
+// Description(opt) interface Name Directives(opt) FieldsDefinition
+func (p *Parser) parseInterfaceDefinition(description string) (intrface InterfaceDefinition) {
+	intrface.Description = description
+	intrface.Name = p.expect(Name).Value
+	intrface.Directives = p.parseDirectives()
+	intrface.Fields = p.parseFieldsDefinition()
+	return
+}
+
+// UnionTypeDefinition
+// Description(opt) union Name Directives(opt) = |(opt) Name (| Name)(list,opt)
+func (p *Parser) parseUnionDefinition(description string) (union UnionDefinition) {
+	union.Description = description
+	union.Name = p.expect(Name).Value
+	union.Directives = p.parseDirectives()
+	p.expect(Equals)
+	p.optional(VerticalBar)
+	union.Types = append(union.Types, p.expect(Name).Value)
+
+	for {
+		if _, separated := p.optional(VerticalBar); !separated {
+			break
+		}
+		union.Types = append(union.Types, p.expect(Name).Value)
+	}
+	return
+}
+
+// EnumTypeDefinition
+// Description(opt) enum Name Directives(opt) { EnumValueDefinition(list) }
+func (p *Parser) parseEnumDefinition(description string) (enum EnumDefinition) {
+	enum.Description = description
+	enum.Name = p.expect(Name).Value
+	enum.Directives = p.parseDirectives()
+
+	p.expect(OpenBrace)
+	for p.peek().Type != ClosedBrace {
+		enum.Values = append(enum.Values, p.parseEnumValueDefinition())
+	}
+	p.expect(ClosedBrace)
+	return
+}
+
+// EnumValueDefinition
+// Description(opt) EnumValue Directives(opt)
+func (p *Parser) parseEnumValueDefinition() (value EnumValueDefinition) {
+	start := p.peek()
+	value.Description = p.parseDescription()
+	value.Name = p.expect(Name).Value
+	value.Directives = p.parseDirectives()
+	value.Loc = p.loc(start)
+	return
+}
+
+// InputObjectTypeDefinition
+// Description(opt) input Name Directives(opt) { InputValueDefinition(list) }
+func (p *Parser) parseInputDefinition(description string) (input InputDefinition) {
+	input.Description = description
+	input.Name = p.expect(Name).Value
+	input.Directives = p.parseDirectives()
+
+	names := make(map[string]struct{})
+	p.expect(OpenBrace)
+	for p.peek().Type != ClosedBrace {
+		start := p.peek()
+		field := p.parseInputValueDefinition()
+
+		if _, exists := names[field.Name]; exists {
+			p.invalid(start, "duplicate field name in input definition")
+		}
+		names[field.Name] = struct{}{}
+		input.Fields = append(input.Fields, field)
+	}
+	p.expect(ClosedBrace)
+	return
+}
+
+// DirectiveDefinition
+// Description(opt) directive @ Name ArgumentsDefinition(opt) repeatable(opt) on DirectiveLocations
+func (p *Parser) parseDirectiveDefinition(description string) (directive DirectiveDefinition) {
+	directive.Description = description
+	p.expect(At)
+	directive.Name = p.expect(Name).Value
+
+	if p.peek().Type == OpenParen {
+		directive.Arguments = p.parseArgumentsDefinition()
+	}
+
+	if token := p.peek(); token.Type == Name && token.Value == "repeatable" {
+		p.take()
+		directive.Repeatable = true
+	}
+
+	p.accept(Name, "on")
+	p.optional(VerticalBar)
+	for {
+		directive.Locations = append(directive.Locations, p.expect(Name).Value)
+
+		if _, separated := p.optional(VerticalBar); !separated {
+			break
+		}
+	}
+	return
+}
+
+// FieldsDefinition
+// { FieldDefinition(list) }
+func (p *Parser) parseFieldsDefinition() (fields []FieldDefinition) {
+	names := make(map[string]struct{})
+
+	p.expect(OpenBrace)
+	for p.peek().Type != ClosedBrace {
+		start := p.peek()
+		field := p.parseFieldDefinition()
+
+		if _, exists := names[field.Name]; exists {
+			p.invalid(start, "duplicate field name in fields definition")
+		}
+		names[field.Name] = struct{}{}
+		fields = append(fields, field)
+	}
+	p.expect(ClosedBrace)
+	return fields
+}
+
+// FieldDefinition
+// Description(opt) Name ArgumentsDefinition(opt) : Type Directives(opt)
+func (p *Parser) parseFieldDefinition() (field FieldDefinition) {
+	start := p.peek()
+	field.Description = p.parseDescription()
+	field.Name = p.expect(Name).Value
+
+	if p.peek().Type == OpenParen {
+		field.Arguments = p.parseArgumentsDefinition()
+	}
+
+	p.expect(Colon)
+	field.Type = p.parseType()
+	field.Directives = p.parseDirectives()
+	field.Loc = p.loc(start)
+	return
+}
+
+// ArgumentsDefinition
+// ( InputValueDefinition(list) )
+func (p *Parser) parseArgumentsDefinition() (arguments []InputValueDefinition) {
+	names := make(map[string]struct{})
+
+	p.expect(OpenParen)
+	for p.peek().Type != ClosedParen {
+		start := p.peek()
+		argument := p.parseInputValueDefinition()
+
+		if _, exists := names[argument.Name]; exists {
+			p.invalid(start, "duplicate argument in arguments definition")
+		}
+		names[argument.Name] = struct{}{}
+		arguments = append(arguments, argument)
+	}
+	p.expect(ClosedParen)
+	return arguments
+}
+
+// InputValueDefinition
+// Description(opt) Name : Type DefaultValue(opt) Directives(opt)
+func (p *Parser) parseInputValueDefinition() (definition InputValueDefinition) {
+	start := p.peek()
+	definition.Description = p.parseDescription()
+	definition.Name = p.expect(Name).Value
+	p.expect(Colon)
+	definition.Type = p.parseType()
+
+	if _, defaultGiven := p.optional(Equals); defaultGiven {
+		definition.Default = p.parseValue()
+	}
+
+	definition.Directives = p.parseDirectives()
+	definition.Loc = p.loc(start)
+	return
+}
@@ -1,12 +1,15 @@
-package graphql
+package language
 
 // Token represents a single GraphQL token from an set of characters
 type Token struct {
 	Type        TokenType
 	Value       string
-	Line        int
+	Line        int // Line of the first rune for this Token
+	LineEnd     int // Line of the last rune for this Token
 	ColumnStart int // Column position of the first rune for this Token
 	ColumnEnd   int // Column position of the last rune for this Token
+	OffsetStart int // Byte offset of the first rune for this Token
+	OffsetEnd   int // Byte offset of the last rune for this Token
 }
 
 // TokenType represents a lexical token for the GraphQL language
@@ -53,6 +56,7 @@ const (
 	OpenBrace     // {
 	ClosedBrace   // }
 	VerticalBar   // |
+	Ampersand     // &
 
 	// [_A-Za-z][_0-9A-Za-z] ASCII only
 	Name // e.g. 'abc'
@@ -69,6 +73,11 @@ const (
 	//    \  EscapedCharacter
 	String
 
+	// """[BlockStringCharacters]""" where BlockStringCharacter is
+	//    SourceCharacter but not """ or \"""
+	//    \""" which is an escaped """
+	BlockString
+
 	// Invalid Token
 	Invalid
 )
@@ -12,10 +12,13 @@ func init() {
 	validNameMatcher = regexp.MustCompile(`^[_a-zA-Z0-9]+$`)
 }
 
-// Builder builds a Schema from provided Declarations
+// Builder builds a Schema from provided Declarations. Problems found while
+// declaring and building the Schema are collected, and reported together by
+// BuildE
 type Builder struct {
 	schema            *Schema
 	declaredTypeNames map[string]interface{}
+	errors            ValidationErrors // Problems found while declaring types and directives
 }
 
 // NewSchema returns a new Schema Builder
@@ -25,7 +28,7 @@ func NewSchema() *Builder {
 		declaredTypeNames: make(map[string]interface{}),
 	}
 
-	// Default Schema
+	// Introspection types
 	builder.Declare(Object{
 		Name: "__Schema",
 		Fields: Fields(
@@ -33,41 +36,59 @@ func NewSchema() *Builder {
 				Name:        "types",
 				Description: "All types that are a part of this Schema",
 				Type:        DescribeNonNullListType(DescribeNonNullType("__Type")),
+				Resolve:     resolveSchemaTypes,
 			},
 			Field{
 				Name:        "queryType",
 				Description: "Root query type for this Schema",
 				Type:        DescribeNonNullType("__Type"),
+				Resolve:     resolveSchemaQueryType,
 			},
 			Field{
 				Name:        "mutationType",
 				Description: "Root mutation type for this Schema",
 				Type:        DescribeType("__Type"),
+				Resolve:     resolveSchemaMutationType,
+			},
+			Field{
+				Name:        "subscriptionType",
+				Description: "Root subscription type for this Schema",
+				Type:        DescribeType("__Type"),
+				Resolve:     resolveSchemaSubscriptionType,
 			},
 			Field{
 				Name:        "directives",
 				Description: "All directives that are a part of this Schema",
 				Type:        DescribeNonNullListType(DescribeNonNullType("__Directive")),
+				Resolve:     resolveSchemaDirectives,
 			},
 		),
 	}).Declare(Object{
 		Name: "__Type",
 		Fields: Fields(
 			Field{
-				Name: "kind",
-				Type: DescribeNonNullType("__TypeKind"),
+				Name:    "kind",
+				Type:    DescribeNonNullType("__TypeKind"),
+				Resolve: resolveTypeKind,
+			},
+			Field{
+				Name:    "name",
+				Type:    StringType,
+				Resolve: resolveTypeName,
 			},
 			Field{
-				Name: "name",
-				Type: NonNullStringType,
+				Name:    "description",
+				Type:    StringType,
+				Resolve: resolveTypeDescription,
 			},
 			Field{
-				Name: "description",
-				Type: StringType,
+				Name:    "specifiedByURL",
+				Type:    StringType,
+				Resolve: resolveTypeSpecifiedByURL,
 			},
 			Field{
 				Name: "fields",
-				Type: DescribeNonNullType("__Field"),
+				Type: DescribeListType(DescribeNonNullType("__Field")),
 				Arguments: Arguments(
 					Argument{
 						Name:    "includeDeprecated",
@@ -75,18 +96,21 @@ func NewSchema() *Builder {
 						Default: false,
 					},
 				),
+				Resolve: filterDeprecated(resolveTypeFields),
 			},
 			Field{
-				Name: "interfaces",
-				Type: DescribeListType(DescribeNonNullType("__Type")),
+				Name:    "interfaces",
+				Type:    DescribeListType(DescribeNonNullType("__Type")),
+				Resolve: resolveTypeInterfaces,
 			},
 			Field{
-				Name: "possibleTypes",
-				Type: DescribeListType(DescribeNonNullType("__Type")),
+				Name:    "possibleTypes",
+				Type:    DescribeListType(DescribeNonNullType("__Type")),
+				Resolve: resolveTypePossibleTypes,
 			},
 			Field{
 				Name: "enumValues",
-				Type: DescribeNonNullType("__EnumValue"),
+				Type: DescribeListType(DescribeNonNullType("__EnumValue")),
 				Arguments: Arguments(
 					Argument{
 						Name:    "includeDeprecated",
@@ -94,102 +118,159 @@ func NewSchema() *Builder {
 						Default: false,
 					},
 				),
+				Resolve: filterDeprecated(resolveTypeEnumValues),
 			},
 			Field{
 				Name: "inputFields",
 				Type: DescribeListType(DescribeNonNullType("__InputValue")),
+				Arguments: Arguments(
+					Argument{
+						Name:    "includeDeprecated",
+						Type:    BooleanType,
+						Default: false,
+					},
+				),
+				Resolve: filterDeprecated(resolveTypeInputFields),
 			},
 			Field{
-				Name: "ofType",
-				Type: DescribeType("__Type"),
+				Name:    "ofType",
+				Type:    DescribeType("__Type"),
+				Resolve: resolveTypeOfType,
 			},
 		),
 	}).Declare(Object{
 		Name: "__Field",
 		Fields: Fields(
 			Field{
-				Name: "name",
-				Type: NonNullStringType,
+				Name:    "name",
+				Type:    NonNullStringType,
+				Resolve: resolveFieldName,
 			},
 			Field{
-				Name: "description",
-				Type: StringType,
+				Name:    "description",
+				Type:    StringType,
+				Resolve: resolveFieldDescription,
 			},
 			Field{
 				Name: "args",
 				Type: DescribeNonNullListType(DescribeNonNullType("__InputValue")),
+				Arguments: Arguments(
+					Argument{
+						Name:    "includeDeprecated",
+						Type:    BooleanType,
+						Default: false,
+					},
+				),
+				Resolve: filterDeprecated(resolveFieldArgs),
 			},
 			Field{
-				Name: "type",
-				Type: DescribeNonNullType("__Type"),
+				Name:    "type",
+				Type:    DescribeNonNullType("__Type"),
+				Resolve: resolveFieldType,
 			},
 			Field{
-				Name: "isDeprecated",
-				Type: NonNullBooleanType,
+				Name:    "isDeprecated",
+				Type:    NonNullBooleanType,
+				Resolve: resolveFieldIsDeprecated,
 			},
 			Field{
-				Name: "deprecationReason",
-				Type: StringType,
+				Name:    "deprecationReason",
+				Type:    StringType,
+				Resolve: resolveFieldDeprecationReason,
 			},
 		),
 	}).Declare(Object{
 		Name: "__InputValue",
 		Fields: Fields(
 			Field{
-				Name: "name",
-				Type: NonNullStringType,
+				Name:    "name",
+				Type:    NonNullStringType,
+				Resolve: resolveInputValueName,
+			},
+			Field{
+				Name:    "description",
+				Type:    StringType,
+				Resolve: resolveInputValueDescription,
 			},
 			Field{
-				Name: "description",
-				Type: StringType,
+				Name:    "type",
+				Type:    DescribeNonNullType("__Type"),
+				Resolve: resolveInputValueType,
 			},
 			Field{
-				Name: "type",
-				Type: DescribeNonNullType("__Type"),
+				Name:    "defaultValue",
+				Type:    StringType,
+				Resolve: resolveInputValueDefaultValue,
 			},
 			Field{
-				Name: "defaultValue",
-				Type: StringType,
+				Name:    "isDeprecated",
+				Type:    NonNullBooleanType,
+				Resolve: resolveInputValueIsDeprecated,
+			},
+			Field{
+				Name:    "deprecationReason",
+				Type:    StringType,
+				Resolve: resolveInputValueDeprecationReason,
 			},
 		),
 	}).Declare(Object{
 		Name: "__EnumValue",
 		Fields: Fields(
 			Field{
-				Name: "name",
-				Type: NonNullStringType,
+				Name:    "name",
+				Type:    NonNullStringType,
+				Resolve: resolveEnumValueName,
 			},
 			Field{
-				Name: "description",
-				Type: StringType,
+				Name:    "description",
+				Type:    StringType,
+				Resolve: resolveEnumValueDescription,
 			},
 			Field{
-				Name: "isDeprecated",
-				Type: NonNullBooleanType,
+				Name:    "isDeprecated",
+				Type:    NonNullBooleanType,
+				Resolve: resolveEnumValueIsDeprecated,
 			},
 			Field{
-				Name: "deprecationReason",
-				Type: StringType,
+				Name:    "deprecationReason",
+				Type:    StringType,
+				Resolve: resolveEnumValueDeprecationReason,
 			},
 		),
 	}).Declare(Object{
 		Name: "__Directive",
 		Fields: Fields(
 			Field{
-				Name: "name",
-				Type: NonNullStringType,
+				Name:    "name",
+				Type:    NonNullStringType,
+				Resolve: resolveDirectiveName,
 			},
 			Field{
-				Name: "description",
-				Type: StringType,
+				Name:    "description",
+				Type:    StringType,
+				Resolve: resolveDirectiveDescription,
 			},
 			Field{
-				Name: "location",
-				Type: DescribeNonNullListType(DescribeNonNullType("__DirectiveLocation")),
+				Name:    "locations",
+				Type:    DescribeNonNullListType(DescribeNonNullType("__DirectiveLocation")),
+				Resolve: resolveDirectiveLocations,
 			},
 			Field{
 				Name: "args",
 				Type: DescribeNonNullListType(DescribeNonNullType("__InputValue")),
+				Arguments: Arguments(
+					Argument{
+						Name:    "includeDeprecated",
+						Type:    BooleanType,
+						Default: false,
+					},
+				),
+				Resolve: filterDeprecated(resolveDirectiveArgs),
+			},
+			Field{
+				Name:    "isRepeatable",
+				Type:    NonNullBooleanType,
+				Resolve: resolveDirectiveIsRepeatable,
 			},
 		),
 	}).Declare(Enum{
@@ -209,70 +290,181 @@ func NewSchema() *Builder {
 		Values: Values(
 			"QUERY",
 			"MUTATION",
+			"SUBSCRIPTION",
 			"FIELD",
 			"FRAGMENT_DEFINITION",
 			"FRAGMENT_SPREAD",
 			"INLINE_FRAGMENT",
+			"VARIABLE_DEFINITION",
+			"SCHEMA",
+			"SCALAR",
+			"OBJECT",
+			"FIELD_DEFINITION",
+			"ARGUMENT_DEFINITION",
+			"INTERFACE",
+			"UNION",
+			"ENUM",
+			"ENUM_VALUE",
+			"INPUT_OBJECT",
+			"INPUT_FIELD_DEFINITION",
+		),
+	}).
+		Declare(IntScalar).
+		Declare(FloatScalar).
+		Declare(StringScalar).
+		Declare(BooleanScalar).
+		Declare(IDScalar)
+
+	// Built-in directives
+	builder.Directive(Directive{
+		Name:        "skip",
+		Description: "Directs the executor to skip this field or fragment when the `if` argument is true.",
+		Arguments: Arguments(
+			Argument{
+				Name:        "if",
+				Description: "Skipped when true.",
+				Type:        NonNullBooleanType,
+			},
+		),
+		Locations: Locations(LocationField, LocationFragmentSpread, LocationInlineFragment),
+	}).Directive(Directive{
+		Name:        "include",
+		Description: "Directs the executor to include this field or fragment only when the `if` argument is true.",
+		Arguments: Arguments(
+			Argument{
+				Name:        "if",
+				Description: "Included when true.",
+				Type:        NonNullBooleanType,
+			},
+		),
+		Locations: Locations(LocationField, LocationFragmentSpread, LocationInlineFragment),
+	}).Directive(Directive{
+		Name:        "deprecated",
+		Description: "Marks an element of a GraphQL schema as no longer supported.",
+		Arguments: Arguments(
+			Argument{
+				Name:        "reason",
+				Description: "Explains why this element was deprecated, usually also including a suggestion for how to access supported similar data.",
+				Type:        StringType,
+				Default:     DefaultDeprecationReason,
+			},
+		),
+		Locations: Locations(LocationFieldDefinition, LocationArgumentDefinition, LocationInputFieldDefinition, LocationEnumValue),
+	}).Directive(Directive{
+		Name:        "specifiedBy",
+		Description: "Exposes a URL that specifies the behavior of this scalar.",
+		Arguments: Arguments(
+			Argument{
+				Name:        "url",
+				Description: "The URL that specifies the behavior of this scalar.",
+				Type:        NonNullStringType,
+			},
 		),
-	}).Declare(Scalar{
-		Name:        "Int",
-		Description: "The Int scalar type represents a signed 32‐bit numeric non‐fractional value. Response formats that support a 32‐bit integer or a number type should use that type to represent this scalar.",
-	}).Declare(Scalar{
-		Name:        "Float",
-		Description: "The Float scalar type represents signed double‐precision fractional values as specified by IEEE 754. Response formats that support an appropriate double‐precision number type should use that type to represent this scalar.",
-	}).Declare(Scalar{
-		Name:        "String",
-		Description: "The String scalar type represents textual data, represented as UTF‐8 character sequences. The String type is most often used by GraphQL to represent free‐form human‐readable text. All response formats must support string representations, and that representation must be used here.",
-	}).Declare(Scalar{
-		Name:        "Boolean",
-		Description: "The Boolean scalar type represents true or false. Response formats should use a built‐in boolean type if supported; otherwise, they should use their representation of the integers 1 and 0.",
-	}).Declare(Scalar{
-		Name:        "ID",
-		Description: "The ID scalar type represents a unique identifier, often used to refetch an object or as the key for a cache. The ID type is serialized in the same way as a String; however, it is not intended to be human‐readable. While it is often numeric, it should always serialize as a String.",
+		Locations: Locations(LocationScalar),
 	})
 
 	return &builder
 }
 
+// err records a ValidationError for the Schema being built
 func (builder *Builder) err(format string, s ...interface{}) {
-	var err error
+	builder.report(validationErr(format, s...))
+}
 
-	if len(s) == 0 {
-		err = NewValidationError(format)
-	} else {
-		err = NewValidationError(fmt.Sprintf(format, s...))
-	}
-	panic(err)
+// errIn records a ValidationError found within the declaration for the Schema
+// being built
+func (builder *Builder) errIn(declaration interface{}, format string, s ...interface{}) {
+	builder.report(within(declaration, validationErr(format, s...)))
+}
+
+// report records err, a ValidationError, for the Schema being built
+func (builder *Builder) report(err error) {
+	builder.errors = append(builder.errors, err.(ValidationError))
 }
 
 // Build builds and validates the Schema. If there are any validation issues
-// Build will panic with a schema validation error describing the problem
+// Build will panic with the first schema validation error describing the
+// problem. Use BuildE to get every validation error instead
 func (builder *Builder) Build() *Schema {
-	for _, scalar := range builder.schema.scalars {
-		builder.validateScalar(scalar)
-	}
-	for _, enum := range builder.schema.enums {
-		builder.validateEnum(enum)
-	}
-	for _, intrface := range builder.schema.interfaces {
-		builder.validateInterface(intrface)
+	schema, err := builder.BuildE()
+	if err != nil {
+		panic(err.(ValidationErrors)[0])
 	}
-	for _, object := range builder.schema.objects {
-		builder.validateObject(object)
+	return schema
+}
+
+// BuildE builds and validates the Schema. If there are any validation issues
+// BuildE returns ValidationErrors describing every problem found while
+// declaring and validating the types. Types are validated in order of name, so
+// the errors are always reported in the same order
+func (builder *Builder) BuildE() (*Schema, error) {
+	// Problems found while declaring are kept, but those found by validating
+	// are reported only by this call so building again does not repeat them
+	declarationErrs := builder.errors
+	builder.errors = append(ValidationErrors(nil), declarationErrs...)
+	defer func() { builder.errors = declarationErrs }()
+
+	// Root types are only found by name if none were set explicitly
+	useDefaults := builder.schema.queryType == "" && builder.schema.mutationType == "" && builder.schema.subscriptionType == ""
+	builder.validateRootType("Query", &builder.schema.queryType, useDefaults, defaultQueryTypeNames)
+	builder.validateRootType("Mutation", &builder.schema.mutationType, useDefaults, defaultMutationTypeNames)
+	builder.validateRootType("Subscription", &builder.schema.subscriptionType, useDefaults, defaultSubscriptionTypeNames)
+
+	for _, declaration := range builder.schema.declarations() {
+		switch d := declaration.(type) {
+		case Scalar:
+			builder.validateScalar(d)
+		case Enum:
+			builder.validateEnum(d)
+		case Interface:
+			builder.validateInterface(d)
+		case Object:
+			builder.validateObject(d)
+		case Union:
+			builder.validateUnion(d)
+		case Input:
+			builder.validateInput(d)
+		}
 	}
-	for _, union := range builder.schema.unions {
-		builder.validateUnion(union)
+	for _, directive := range builder.schema.Directives() {
+		builder.validateDirective(directive)
 	}
-	for _, input := range builder.schema.inputs {
-		builder.validateInput(input)
+
+	if len(builder.errors) > 0 {
+		return nil, builder.errors
 	}
-	return builder.schema
+	return builder.schema, nil
+}
+
+// Query sets the name of the Object type used as the root of query
+// operations. If no root type names are set, an Object named Query or
+// QueryRoot is used
+func (builder *Builder) Query(name string) *Builder {
+	builder.schema.queryType = name
+	return builder
+}
+
+// Mutation sets the name of the Object type used as the root of mutation
+// operations. If no root type names are set, an Object named Mutation or
+// MutationRoot is used
+func (builder *Builder) Mutation(name string) *Builder {
+	builder.schema.mutationType = name
+	return builder
+}
+
+// Subscription sets the name of the Object type used as the root of
+// subscription operations. If no root type names are set, an Object named
+// Subscription or SubscriptionRoot is used
+func (builder *Builder) Subscription(name string) *Builder {
+	builder.schema.subscriptionType = name
+	return builder
 }
 
 // Enum adds a new Enum type declaration to the Schema
 func (builder *Builder) Enum(enum Enum) *Builder {
 	if err := builder.declareTypeName(enum); err != nil {
-		builder.err("Enum %s", err)
+		builder.report(within("Enum", err))
+		return builder
 	}
 	builder.schema.enums[enum.Name] = enum
 	return builder
@@ -281,7 +473,8 @@ func (builder *Builder) Enum(enum Enum) *Builder {
 // Input adds a new Input type declaration to the Schema
 func (builder *Builder) Input(input Input) *Builder {
 	if err := builder.declareTypeName(input); err != nil {
-		builder.err("Input %s", err)
+		builder.report(within("Input", err))
+		return builder
 	}
 	builder.schema.inputs[input.Name] = input
 	return builder
@@ -290,18 +483,20 @@ func (builder *Builder) Input(input Input) *Builder {
 // Interface adds a new Interface type declaration to the Schema
 func (builder *Builder) Interface(intrface Interface) *Builder {
 	if err := builder.declareTypeName(intrface); err != nil {
-		builder.err("Interface %s", err)
+		builder.report(within("Interface", err))
+		return builder
 	}
 	builder.schema.interfaces[intrface.Name] = intrface
 	return builder
 }
 
-// Object adds a new Object type declaration to the Schema. A panic will occur
-// if the Object is declared with an invalid Name, or a Type with that Name has
-// already been declared
+// Object adds a new Object type declaration to the Schema. An Object declared
+// with an invalid Name, or with the Name of a Type that has already been
+// declared, is not added and is reported as an error by Build
 func (builder *Builder) Object(object Object) *Builder {
 	if err := builder.declareTypeName(object); err != nil {
-		builder.err("Object %s", err)
+		builder.report(within("Object", err))
+		return builder
 	}
 	builder.schema.objects[object.Name] = object
 	return builder
@@ -310,7 +505,8 @@ func (builder *Builder) Object(object Object) *Builder {
 // Scalar adds a new Scalar type declaration to the Schema
 func (builder *Builder) Scalar(scalar Scalar) *Builder {
 	if err := builder.declareTypeName(scalar); err != nil {
-		builder.err("Scalar %s", err)
+		builder.report(within("Scalar", err))
+		return builder
 	}
 	builder.schema.scalars[scalar.Name] = scalar
 	return builder
@@ -319,12 +515,31 @@ func (builder *Builder) Scalar(scalar Scalar) *Builder {
 // Union adds a new Union type declaration to the Schema
 func (builder *Builder) Union(union Union) *Builder {
 	if err := builder.declareTypeName(union); err != nil {
-		builder.err("Union %s", err)
+		builder.report(within("Union", err))
+		return builder
 	}
 	builder.schema.unions[union.Name] = union
 	return builder
 }
 
+// Directive adds a new Directive declaration to the Schema
+func (builder *Builder) Directive(directive Directive) *Builder {
+	if err := builder.validateName(directive.Name); err != nil {
+		builder.report(within("Directive", err))
+		return builder
+	}
+
+	if _, exists := builder.schema.directives[directive.Name]; exists {
+		builder.report(within("Directive", ValidationError{
+			Directive: directive.Name,
+			Message:   fmt.Sprintf("declared with name '%s' but another directive with that name has already been declared", directive.Name),
+		}))
+		return builder
+	}
+	builder.schema.directives[directive.Name] = directive
+	return builder
+}
+
 // Declare a new schema type
 func (builder *Builder) Declare(declaration Declaration) *Builder {
 	switch v := declaration.(type) {
@@ -348,13 +563,82 @@ func (builder *Builder) Declare(declaration Declaration) *Builder {
 	return builder
 }
 
+// Resolve sets the ResolveFunc of a Field of a declared Object, such as an
+// Object declared by ParseSDL. Setting the ResolveFunc of a Field that has not
+// been declared is reported as an error by Build
+func (builder *Builder) Resolve(typeName, fieldName string, resolve ResolveFunc) *Builder {
+	object, exists := builder.schema.objects[typeName]
+	if !exists {
+		builder.report(ValidationError{
+			Type:    typeName,
+			Field:   fieldName,
+			Message: fmt.Sprintf("ResolveFunc set for Field(%s) of undeclared Object '%s'", fieldName, typeName),
+		})
+		return builder
+	}
+
+	field, exists := object.Fields[fieldName]
+	if !exists {
+		builder.report(within(object, ValidationError{
+			Field:   fieldName,
+			Message: fmt.Sprintf("ResolveFunc set for undeclared Field '%s'", fieldName),
+		}))
+		return builder
+	}
+	field.Resolve = resolve
+	object.Fields[fieldName] = field
+	return builder
+}
+
+// ResolveType sets the ResolveTypeFunc of a declared Interface or Union, such
+// as one declared by ParseSDL. Setting the ResolveTypeFunc of any other type is
+// reported as an error by Build
+func (builder *Builder) ResolveType(typeName string, resolveType ResolveTypeFunc) *Builder {
+	if intrface, exists := builder.schema.interfaces[typeName]; exists {
+		intrface.ResolveType = resolveType
+		builder.schema.interfaces[typeName] = intrface
+	} else if union, exists := builder.schema.unions[typeName]; exists {
+		union.ResolveType = resolveType
+		builder.schema.unions[typeName] = union
+	} else {
+		builder.report(ValidationError{
+			Type:    typeName,
+			Message: fmt.Sprintf("ResolveTypeFunc set for undeclared Interface or Union '%s'", typeName),
+		})
+	}
+	return builder
+}
+
+// ScalarFuncs sets the coercion functions of a declared Scalar, such as one
+// declared by ParseSDL. Setting the functions of a Scalar that has not been
+// declared is reported as an error by Build
+func (builder *Builder) ScalarFuncs(typeName string, serialize, parseValue, parseLiteral CoerceFunc) *Builder {
+	scalar, exists := builder.schema.scalars[typeName]
+	if !exists {
+		builder.report(ValidationError{
+			Type:    typeName,
+			Message: fmt.Sprintf("Scalar functions set for undeclared Scalar '%s'", typeName),
+		})
+		return builder
+	}
+
+	scalar.Serialize = serialize
+	scalar.ParseValue = parseValue
+	scalar.ParseLiteral = parseLiteral
+	builder.schema.scalars[typeName] = scalar
+	return builder
+}
+
 func (builder *Builder) declareTypeName(declaration Declaration) error {
 	if err := builder.validateName(declaration.GetName()); err != nil {
 		return err
 	}
 
 	if _, exists := builder.declaredTypeNames[declaration.GetName()]; exists {
-		return fmt.Errorf("declared with name '%s' but another type with that name has already been declared", declaration.GetName())
+		return ValidationError{
+			Type:    declaration.GetName(),
+			Message: fmt.Sprintf("declared with name '%s' but another type with that name has already been declared", declaration.GetName()),
+		}
 	}
 	builder.declaredTypeNames[declaration.GetName()] = struct{}{}
 	return nil
@@ -368,34 +652,42 @@ func (builder *Builder) declareTypeName(declaration Declaration) error {
 
 func (builder *Builder) validateEnum(enum Enum) {
 	if len(enum.Values) == 0 {
-		builder.err("%s delcared without any values defined", enum)
+		builder.errIn(enum, "delcared without any values defined")
 	}
 
-	if duplicate := findFirstDuplicate(enum.Values); duplicate != nil {
-		builder.err("%s declared duplicate value %s", enum, *duplicate)
+	names := make([]string, len(enum.Values))
+	for i, value := range enum.Values {
+		names[i] = value.Name
+		if err := builder.validateName(value.Name); err != nil {
+			builder.report(within(enum, within("EnumValue", err)))
+		}
+	}
+
+	if duplicate := findFirstDuplicate(names); duplicate != nil {
+		builder.errIn(enum, "declared duplicate value %s", *duplicate)
 	}
 }
 
 // http://facebook.github.io/graphql/October2016/#sec-Input-Object-type-validation
 func (builder *Builder) validateInput(input Input) {
 	if len(input.Fields) == 0 {
-		builder.err("%s declared without any Fields defined", input)
+		builder.errIn(input, "declared without any Fields defined")
 	}
 
-	for _, field := range input.Fields {
+	for _, field := range sortedFields(input.Fields) {
 		if err := builder.validateInputField(field); err != nil {
-			builder.err("%s %s", input, err)
+			builder.report(within(input, err))
 		}
 	}
 }
 
 func (builder *Builder) validateInputField(field Field) error {
 	if err := builder.validateName(field.Name); err != nil {
-		return fmt.Errorf("Field %s", err)
+		return within("Field", err)
 	}
 
 	if err := builder.validateType(field.Type); err != nil {
-		return fmt.Errorf("%s %s", field, err)
+		return within(field, err)
 	}
 
 	// Input field types can only be input, scalar, or enum
@@ -404,12 +696,25 @@ func (builder *Builder) validateInputField(field Field) error {
 	case SCALAR:
 	case ENUM:
 	default:
-		return fmt.Errorf("%s declared with invalid Type '%s'. An Input Field type must be Input, Scalar, or Enum", field, field.Type)
+		return within(field, validationErr("declared with invalid Type '%s'. An Input Field type must be Input, Scalar, or Enum", field.Type))
 	}
 
 	// Input fields cannot be declared with arguments
 	if len(field.Arguments) > 0 {
-		return fmt.Errorf("%s declared with arguments. Input fields must be declared without arguments", field)
+		return within(field, validationErr("declared with arguments. Input fields must be declared without arguments"))
+	}
+
+	// Input fields are never resolved, so a ResolveFunc would be ignored
+	if field.Resolve != nil {
+		return within(field, validationErr("declared with a ResolveFunc. Input fields must be declared without a ResolveFunc"))
+	}
+
+	if field.Type.NonNull && field.Default != nil {
+		return within(field, validationErr("declared with a default value, but its type is non-null"))
+	}
+
+	if field.Type.NonNull && field.DeprecationReason != "" {
+		return within(field, validationErr("declared deprecated with a non-null type. Required input fields cannot be deprecated"))
 	}
 	return nil
 }
@@ -417,12 +722,12 @@ func (builder *Builder) validateInputField(field Field) error {
 // http://facebook.github.io/graphql/October2016/#sec-Interface-type-validation
 func (builder *Builder) validateInterface(intrface Interface) {
 	if len(intrface.Fields) == 0 {
-		builder.err("%s declared without any Fields defined", intrface)
+		builder.errIn(intrface, "declared without any Fields defined")
 	}
 
-	for _, field := range intrface.Fields {
-		if err := builder.validateField(field); err != nil {
-			builder.err("%s %s", intrface, err)
+	for _, field := range sortedFields(intrface.Fields) {
+		for _, err := range builder.validateField(field) {
+			builder.report(within(intrface, err))
 		}
 	}
 }
@@ -431,121 +736,166 @@ func (builder *Builder) validateInterface(intrface Interface) {
 func (builder *Builder) validateObject(object Object) {
 
 	if len(object.Fields) == 0 {
-		builder.err("%s declared without any Fields defined", object)
+		builder.errIn(object, "declared without any Fields defined")
 	}
 
-	for _, field := range object.Fields {
-		if err := builder.validateField(field); err != nil {
-			builder.err("%s %s", object, err)
+	for _, field := range sortedFields(object.Fields) {
+		for _, err := range builder.validateField(field) {
+			builder.report(within(object, err))
 		}
 	}
 
 	for _, interfaceName := range object.Implements {
 		if intrface, err := builder.schema.getInterface(interfaceName); err == nil {
-			for _, interfaceField := range intrface.Fields {
+			for _, interfaceField := range sortedFields(intrface.Fields) {
 				if objectField, exists := object.Fields[interfaceField.Name]; exists {
-					if err := builder.validateFieldImplementsInterface(objectField, interfaceField, intrface); err != nil {
-						builder.err("%s %s", object, err)
+					for _, err := range builder.validateFieldImplementsInterface(objectField, interfaceField, intrface) {
+						builder.report(within(object, err))
 					}
 				} else {
-					builder.err("%s declared without %s required by %s", object, interfaceField, intrface)
+					builder.errIn(object, "declared without %s required by %s", interfaceField, intrface)
 				}
 			}
 		} else {
-			builder.err("%s declared implementing unknown Interface '%s'", object, interfaceName)
+			builder.errIn(object, "declared implementing unknown Interface '%s'", interfaceName)
+		}
+	}
+}
+
+// validateRootType ensures a root type name refers to a declared Object. If no
+// name was set and useDefaults is true, the first declared Object with one of
+// the default names is used
+func (builder *Builder) validateRootType(operationType string, name *string, useDefaults bool, defaultNames []string) {
+	if *name == "" {
+		if useDefaults {
+			*name = builder.schema.findRootType(defaultNames)
+		}
+		return
+	}
+
+	if _, err := builder.schema.getObject(*name); err != nil {
+		builder.err("%s root type '%s' must be a declared Object", operationType, *name)
+	}
+}
+
+func (builder *Builder) validateDirective(directive Directive) {
+	if len(directive.Locations) == 0 {
+		builder.errIn(directive, "declared without any locations defined")
+	}
+
+	for _, location := range directive.Locations {
+		if _, valid := directiveLocations[location]; !valid {
+			builder.errIn(directive, "declared with unknown location '%s'", location)
+		}
+	}
+
+	for _, argument := range sortedArguments(directive.Arguments) {
+		if err := builder.validateArgument(argument); err != nil {
+			builder.report(within(directive, err))
 		}
 	}
 }
 
+// Input values are given to a Scalar either as variables or as literals, so a
+// Scalar that parses one must parse both
 func (builder *Builder) validateScalar(scalar Scalar) {
-	// NOTE: Nothing to validate for now
+	if scalar.ParseValue != nil && scalar.ParseLiteral == nil {
+		builder.errIn(scalar, "declared with a ParseValue function but without a ParseLiteral function")
+	}
+	if scalar.ParseLiteral != nil && scalar.ParseValue == nil {
+		builder.errIn(scalar, "declared with a ParseLiteral function but without a ParseValue function")
+	}
 }
 
 // http://facebook.github.io/graphql/October2016/#sec-Union-type-validation
 func (builder *Builder) validateUnion(union Union) {
 	if len(union.Types) == 0 {
-		builder.err("%s declared without any member types defined", union)
+		builder.errIn(union, "declared without any member types defined")
 	}
 
 	for _, unionTypeName := range union.Types {
 		if declaration := builder.schema.GetDeclaration(DescribeType(unionTypeName)); declaration == nil {
-			builder.err("%s declared with unknown type %s", union, unionTypeName)
+			builder.errIn(union, "declared with unknown type %s", unionTypeName)
 		} else if declaration.TypeKind() != OBJECT {
-			builder.err("%s declared with member type %s. Union members must be Objects", union, unionTypeName)
+			builder.errIn(union, "declared with member type %s. Union members must be Objects", unionTypeName)
 		}
 	}
 
 	if duplicate := findFirstDuplicate(union.Types); duplicate != nil {
-		builder.err("%s declared duplicate type %s", union, *duplicate)
+		builder.errIn(union, "declared duplicate type %s", *duplicate)
 	}
 }
 
-// Vaidate a Object or Interface field. Use validateInputField for Input types
-func (builder *Builder) validateField(field Field) error {
+// Vaidate a Object or Interface field. Use validateInputField for Input types.
+// Returns every problem found with the field and its Arguments
+func (builder *Builder) validateField(field Field) (errs []error) {
 	if err := builder.validateName(field.Name); err != nil {
-		return fmt.Errorf("Field %s", err)
+		return []error{within("Field", err)}
 	}
 
 	if err := builder.validateType(field.Type); err != nil {
-		return fmt.Errorf("%s %s", field, err)
+		errs = append(errs, within(field, err))
+	} else if builder.schema.GetDeclaration(field.Type).TypeKind() == INPUT_OBJECT {
+		errs = append(errs, within(field, validationErr("declared with Input type '%s'", field.Type)))
 	}
 
-	if builder.schema.GetDeclaration(field.Type).TypeKind() == INPUT_OBJECT {
-		return fmt.Errorf("%s declared with Input type '%s'", field, field.Type)
+	if field.Default != nil {
+		errs = append(errs, within(field, validationErr("declared with a default value. Only Input fields may declare default values")))
 	}
 
-	for _, argument := range field.Arguments {
+	for _, argument := range sortedArguments(field.Arguments) {
 		if err := builder.validateArgument(argument); err != nil {
-			return fmt.Errorf("%s %s", field, err)
+			errs = append(errs, within(field, err))
 		}
 	}
-	return nil
+	return errs
 }
 
-func (builder *Builder) validateFieldImplementsInterface(objectField, interfaceField Field, intrface Interface) error {
-	if builder.covariantTypeCheck(objectField.Type, interfaceField.Type) {
+// validateFieldImplementsInterface returns every way the Object's field fails
+// to implement the Interface's field
+func (builder *Builder) validateFieldImplementsInterface(objectField, interfaceField Field, intrface Interface) (errs []error) {
+	if !builder.covariantTypeCheck(objectField.Type, interfaceField.Type) {
+		return []error{within(objectField, validationErr("declared with type '%s' but %s requires the type '%s' or a valid sub-type", objectField.Type, intrface, interfaceField.Type))}
+	}
 
-		// Validate all interface Field Arguments are implemented
-		for _, interfaceArgument := range interfaceField.Arguments {
-			if objectArgument, exists := objectField.Arguments[interfaceArgument.Name]; exists {
-				if err := builder.validateArgumentImplementsInterface(objectArgument, interfaceArgument, intrface); err != nil {
-					return fmt.Errorf("%s %s", objectField, err)
-				}
-			} else {
-				return fmt.Errorf("%s declared without %s required by %s", objectField, interfaceArgument, intrface)
+	// Validate all interface Field Arguments are implemented
+	for _, interfaceArgument := range sortedArguments(interfaceField.Arguments) {
+		if objectArgument, exists := objectField.Arguments[interfaceArgument.Name]; exists {
+			if err := builder.validateArgumentImplementsInterface(objectArgument, interfaceArgument, intrface); err != nil {
+				errs = append(errs, within(objectField, err))
 			}
+		} else {
+			errs = append(errs, within(objectField, validationErr("declared without %s required by %s", interfaceArgument, intrface)))
 		}
+	}
 
-		// Validate all aditional Field Arguments are not required
-		for _, objectArgument := range objectField.Arguments {
-			if _, exists := interfaceField.Arguments[objectArgument.Name]; !exists {
-				if objectArgument.Type.NonNull {
-					return fmt.Errorf("%s declared an additional %s with a non-null type. Since %s is required by %s any additional Arguments must not be required", objectField, objectArgument, interfaceField, intrface)
-				}
+	// Validate all aditional Field Arguments are not required
+	for _, objectArgument := range sortedArguments(objectField.Arguments) {
+		if _, exists := interfaceField.Arguments[objectArgument.Name]; !exists {
+			if objectArgument.Type.NonNull {
+				errs = append(errs, within(objectField, validationErr("declared an additional %s with a non-null type. Since %s is required by %s any additional Arguments must not be required", objectArgument, interfaceField, intrface)))
 			}
 		}
-	} else {
-		return fmt.Errorf("%s declared with type '%s' but %s requires the type '%s' or a valid sub-type", objectField, objectField.Type, intrface, interfaceField.Type)
 	}
-	return nil
+	return errs
 }
 
 // TODO determine what to do with default values, if interface defines default,
 //      does the object default need to be the same, or can it differ?
 func (builder *Builder) validateArgumentImplementsInterface(objectArg, interfaceArg Argument, intrface Interface) error {
 	if !typeCheck(objectArg.Type, interfaceArg.Type) {
-		return fmt.Errorf("%s declared with type '%s' but %s requires type '%s'", objectArg, objectArg.Type, intrface, interfaceArg.Type)
+		return within(objectArg, validationErr("declared with type '%s' but %s requires type '%s'", objectArg.Type, intrface, interfaceArg.Type))
 	}
 	return nil
 }
 
 func (builder *Builder) validateArgument(argument Argument) error {
 	if err := builder.validateName(argument.Name); err != nil {
-		return fmt.Errorf("Argument: %s", err)
+		return within("Argument:", err)
 	}
 
 	if err := builder.validateType(argument.Type); err != nil {
-		return fmt.Errorf("%s %s", argument, err)
+		return within(argument, err)
 	}
 
 	// Input field types can only be input, scalar, or enum
@@ -554,11 +904,15 @@ func (builder *Builder) validateArgument(argument Argument) error {
 	case SCALAR:
 	case ENUM:
 	default:
-		return fmt.Errorf("%s declared with invalid type '%s'. An Argument Type must be Input, Scalar, or Enum", argument, argument.Type)
+		return within(argument, validationErr("declared with invalid type '%s'. An Argument Type must be Input, Scalar, or Enum", argument.Type))
 	}
 
 	if argument.Type.NonNull && argument.Default != nil {
-		return fmt.Errorf("%s declared with a default value, but its type is non-null", argument)
+		return within(argument, validationErr("declared with a default value, but its type is non-null"))
+	}
+
+	if argument.Type.NonNull && argument.DeprecationReason != "" {
+		return within(argument, validationErr("declared deprecated with a non-null type. Required arguments cannot be deprecated"))
 	}
 	return nil
 	// TODO validate default value?
@@ -566,11 +920,11 @@ func (builder *Builder) validateArgument(argument Argument) error {
 
 func (builder *Builder) validateName(name string) error {
 	if name == "" {
-		return fmt.Errorf("declared without Name defined")
+		return validationErr("declared without Name defined")
 	}
 
 	if !validNameMatcher.MatchString(name) {
-		return fmt.Errorf("declared with an invalid Name '%s'. A Name must only consist of ASCII letters, numbers, and underscores", name)
+		return validationErr("declared with an invalid Name '%s'. A Name must only consist of ASCII letters, numbers, and underscores", name)
 	}
 	return nil
 }
@@ -584,17 +938,17 @@ func (builder *Builder) validateType(t Type) error {
 		}
 
 		if baseType.SubType == nil {
-			return fmt.Errorf("type declared with a nil sub-type")
+			return validationErr("type declared with a nil sub-type")
 		}
-		baseType = *t.SubType
+		baseType = *baseType.SubType
 	}
 
 	if err := builder.validateName(baseType.Name); err != nil {
-		return fmt.Errorf("type %s", err)
+		return within("type", err)
 	}
 
 	if declaration := builder.schema.GetDeclaration(baseType); declaration == nil {
-		return fmt.Errorf("declared with unknown type '%s'", baseType.Name)
+		return validationErr("declared with unknown type '%s'", baseType.Name)
 	}
 	return nil
 }
@@ -670,11 +1024,21 @@ func Interfaces(interfaces ...string) []string {
 	return interfaces
 }
 
-// Values builds a list of string Values
-func Values(values ...string) []string {
+// Values builds a list of EnumValues from their names. EnumValues with
+// descriptions, deprecation reasons, or Go values are declared directly
+func Values(names ...string) []EnumValue {
+	values := make([]EnumValue, len(names))
+	for i, name := range names {
+		values[i] = EnumValue{Name: name}
+	}
 	return values
 }
 
+// Locations builds a list of DirectiveLocations
+func Locations(locations ...DirectiveLocation) []DirectiveLocation {
+	return locations
+}
+
 // Types builds a list of Type names
 func Types(types ...string) []string {
 	return types
@@ -77,15 +77,15 @@ func TestEnumType(t *testing.T) {
 
 	testEnum, err := schema.getEnum("TestEnum")
 	assert.Nil(t, err)
-	assert.Equal(t, []string{"TEST_A", "TEST_B", "TEST_C"}, testEnum.Values)
+	assert.Equal(t, Values("TEST_A", "TEST_B", "TEST_C"), testEnum.Values)
 
 	singleValueEnum, err := schema.getEnum("SingleValueEnum")
 	assert.Nil(t, err)
-	assert.Equal(t, []string{"JUST_ME"}, singleValueEnum.Values)
+	assert.Equal(t, Values("JUST_ME"), singleValueEnum.Values)
 
 	differentCharacterCasesEnum, err := schema.getEnum("DifferentCharacterCasesEnum")
 	assert.Nil(t, err)
-	assert.Equal(t, []string{"lowercase", "UPPERCASE", "lowerCamelCase", "UpperCamelCase", "lower_snake_case", "UPPER_SNAKE_CASE", "withNumber1", "1", "_2", "3_4", "5_6_"}, differentCharacterCasesEnum.Values)
+	assert.Equal(t, Values("lowercase", "UPPERCASE", "lowerCamelCase", "UpperCamelCase", "lower_snake_case", "UPPER_SNAKE_CASE", "withNumber1", "1", "_2", "3_4", "5_6_"), differentCharacterCasesEnum.Values)
 }
 
 func TestScalarType(t *testing.T) {
@@ -452,6 +452,56 @@ func TestUnionType(t *testing.T) {
 	assert.Equal(t, []string{"Object1", "Object2"}, union.Types)
 }
 
+func TestRootTypes(t *testing.T) {
+	schema := NewSchema().
+		Query("Root").
+		Mutation("Change").
+		Declare(Object{
+			Name:   "Root",
+			Fields: TestObject.Fields,
+		}).
+		Declare(Object{
+			Name:   "Change",
+			Fields: TestObject.Fields,
+		}).
+		Declare(Object{
+			Name:   "Subscription",
+			Fields: TestObject.Fields,
+		}).Build()
+	assert.NotNil(t, schema)
+
+	queryType, supported := schema.QueryType()
+	assert.True(t, supported)
+	assert.Equal(t, "Root", queryType.Name)
+
+	mutationType, supported := schema.MutationType()
+	assert.True(t, supported)
+	assert.Equal(t, "Change", mutationType.Name)
+
+	// Objects are not used as root types by name once any root type is set
+	_, supported = schema.SubscriptionType()
+	assert.False(t, supported)
+}
+
+func TestDefaultRootTypes(t *testing.T) {
+	schema := NewSchema().
+		Declare(Object{
+			Name:   "QueryRoot",
+			Fields: TestObject.Fields,
+		}).Build()
+	assert.NotNil(t, schema)
+
+	queryType, supported := schema.QueryType()
+	assert.True(t, supported)
+	assert.Equal(t, "QueryRoot", queryType.Name)
+
+	_, supported = schema.MutationType()
+	assert.False(t, supported)
+
+	_, supported = schema.SubscriptionType()
+	assert.False(t, supported)
+}
+
 ///
 // Invalid Schema Tests
 ///
@@ -474,7 +524,194 @@ func TestInvalidSchemaMultipleDeclarationsWithTheSameName(t *testing.T) {
 				Name: "Duplicate",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestBuildEReportsAllErrors(t *testing.T) {
+	expected := ValidationErrors{
+		NewValidationError("Object declared with name 'Duplicate' but another type with that name has already been declared"),
+		NewValidationError("Query root type 'Query' must be a declared Object"),
+		NewValidationError("Object(Dog) Field(barkVolume) declared with unknown type 'Number'"),
+		NewValidationError("Object(Dog) Field(name) Argument(format) declared with invalid type 'Dog'. An Argument Type must be Input, Scalar, or Enum"),
+		NewValidationError("Object(Dog) declared implementing unknown Interface 'Pet'"),
+		NewValidationError("Union(Duplicate) declared without any member types defined"),
+		NewValidationError("Enum(Test) delcared without any values defined"),
+	}
+
+	schema, err := NewSchema().
+		Query("Query").
+		Declare(Enum{
+			Name: "Test",
+		}).
+		Declare(Union{
+			Name: "Duplicate",
+		}).
+		Declare(Object{
+			Name: "Duplicate",
+		}).
+		Declare(Object{
+			Name:       "Dog",
+			Implements: Interfaces("Pet"),
+			Fields: Fields(
+				Field{
+					Name: "name",
+					Type: StringType,
+					Arguments: Arguments(
+						Argument{
+							Name: "format",
+							Type: DescribeType("Dog"),
+						},
+					),
+				},
+				Field{
+					Name: "barkVolume",
+					Type: DescribeType("Number"),
+				},
+			),
+		}).BuildE()
+	assert.Nil(t, schema)
+	assert.EqualError(t, err, expected.Error())
+}
+
+func TestBuildEValidationErrorFields(t *testing.T) {
+	_, err := NewSchema().
+		Declare(Object{
+			Name: "Dog",
+			Fields: Fields(
+				Field{
+					Name: "name",
+					Type: StringType,
+					Arguments: Arguments(
+						Argument{
+							Name: "format",
+							Type: DescribeType("Dog"),
+						},
+					),
+				},
+			),
+		}).
+		Directive(Directive{
+			Name:      "cached",
+			Locations: Locations("NOWHERE"),
+		}).BuildE()
+
+	errs := err.(ValidationErrors)
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "Dog", errs[0].Type)
+	assert.Equal(t, "name", errs[0].Field)
+	assert.Equal(t, "format", errs[0].Argument)
+	assert.Equal(t, "declared with invalid type 'Dog'. An Argument Type must be Input, Scalar, or Enum", errs[0].Message)
+	assert.Equal(t, "cached", errs[1].Directive)
+	assert.Equal(t, "declared with unknown location 'NOWHERE'", errs[1].Message)
+}
+
+func TestBuildETwice(t *testing.T) {
+	builder := NewSchema().
+		Declare(Object{Name: "Duplicate"}).
+		Declare(Object{Name: "Duplicate"})
+
+	expected := ValidationErrors{
+		NewValidationError("Object declared with name 'Duplicate' but another type with that name has already been declared"),
+		NewValidationError("Object(Duplicate) declared without any Fields defined"),
+	}
+	for i := 0; i < 2; i++ {
+		_, err := builder.BuildE()
+		assert.EqualError(t, err, expected.Error())
+	}
+}
+
+func TestBuildEValidSchema(t *testing.T) {
+	schema, err := NewSchema().Declare(TestObject).BuildE()
+	assert.Nil(t, err)
+	assert.NotNil(t, schema)
+}
+
+func TestDirective(t *testing.T) {
+	cached := Directive{
+		Name:        "cached",
+		Description: "Caches the value of a field",
+		Arguments: Arguments(
+			Argument{
+				Name: "seconds",
+				Type: IntType,
+			},
+		),
+		Repeatable: true,
+		Locations:  Locations(LocationFieldDefinition, LocationObject),
+	}
+
+	schema := NewSchema().
+		Declare(TestObject).
+		Directive(cached).Build()
+
+	directive, exists := schema.GetDirective("cached")
+	assert.True(t, exists)
+	assert.Equal(t, cached, directive)
+	assert.True(t, directive.AllowsLocation(LocationObject))
+	assert.False(t, directive.AllowsLocation(LocationField))
+
+	var names []string
+	for _, directive := range schema.Directives() {
+		names = append(names, directive.Name)
+	}
+	assert.Equal(t, []string{"cached", "deprecated", "include", "skip", "specifiedBy"}, names)
+}
+
+func TestInvalidDirectives(t *testing.T) {
+	expected := ValidationErrors{
+		NewValidationError("Directive declared with name 'skip' but another directive with that name has already been declared"),
+		NewValidationError("Directive declared with an invalid Name 'not valid'. A Name must only consist of ASCII letters, numbers, and underscores"),
+		NewValidationError("Directive(@cached) declared with unknown location 'NOWHERE'"),
+		NewValidationError("Directive(@cached) Argument(seconds) declared with invalid type 'TestObject'. An Argument Type must be Input, Scalar, or Enum"),
+		NewValidationError("Directive(@empty) declared without any locations defined"),
+	}
+
+	_, err := NewSchema().
+		Declare(TestObject).
+		Directive(Directive{
+			Name:      "skip",
+			Locations: Locations(LocationField),
+		}).
+		Directive(Directive{
+			Name:      "not valid",
+			Locations: Locations(LocationField),
+		}).
+		Directive(Directive{
+			Name: "cached",
+			Arguments: Arguments(
+				Argument{
+					Name: "seconds",
+					Type: DescribeType("TestObject"),
+				},
+			),
+			Locations: Locations(LocationField, "NOWHERE"),
+		}).
+		Directive(Directive{
+			Name: "empty",
+		}).BuildE()
+	assert.EqualError(t, err, expected.Error())
+}
+
+func TestInvalidRootTypeUndeclared(t *testing.T) {
+	expected := NewValidationError("Query root type 'Query' must be a declared Object")
+
+	actual := CapturePanic(func() {
+		NewSchema().
+			Query("Query").
+			Declare(TestObject).Build()
+	})
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestInvalidRootTypeNotAnObject(t *testing.T) {
+	expected := NewValidationError("Mutation root type 'TestInterface' must be a declared Object")
+
+	actual := CapturePanic(func() {
+		NewSchema().
+			Mutation("TestInterface").
+			Declare(TestInterface).Build()
+	})
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidTypeNameUndeclared(t *testing.T) {
@@ -492,7 +729,7 @@ func TestInvalidTypeNameUndeclared(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidTypeNameCharacters(t *testing.T) {
@@ -510,7 +747,7 @@ func TestInvalidTypeNameCharacters(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidListTypeWithNilBaseType(t *testing.T) {
@@ -530,7 +767,7 @@ func TestInvalidListTypeWithNilBaseType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 ///
@@ -544,7 +781,7 @@ func TestInvalidEnumNameUndeclared(t *testing.T) {
 		NewSchema().
 			Declare(Enum{}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidEnumNameCharacters(t *testing.T) {
@@ -556,7 +793,7 @@ func TestInvalidEnumNameCharacters(t *testing.T) {
 				Name: "Some Enum With Spaces",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidEnumWithoutValues(t *testing.T) {
@@ -568,7 +805,7 @@ func TestInvalidEnumWithoutValues(t *testing.T) {
 				Name: "Test",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidEnumWithDuplicateValues(t *testing.T) {
@@ -581,7 +818,49 @@ func TestInvalidEnumWithDuplicateValues(t *testing.T) {
 				Values: Values("TEST_A", "TEST_B", "TEST_C", "TEST_B", "TEST_D"),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestInvalidEnumValueName(t *testing.T) {
+	expected := NewValidationError("Enum(Test) EnumValue declared with an invalid Name 'TEST-B'. A Name must only consist of ASCII letters, numbers, and underscores")
+
+	actual := CapturePanic(func() {
+		NewSchema().
+			Declare(Enum{
+				Name: "Test",
+				Values: []EnumValue{
+					{Name: "TEST_A", Value: 1},
+					{Name: "TEST-B", Value: 2},
+				},
+			}).Build()
+	})
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestEnumValueOf(t *testing.T) {
+	type color string
+	enum := Enum{
+		Name: "Color",
+		Values: []EnumValue{
+			{Name: "RED", Value: 1},
+			{Name: "GREEN"},
+			{Name: "BLUE", Value: []int{3}},
+		},
+	}
+
+	value, exists := enum.ValueOf(1)
+	assert.True(t, exists)
+	assert.Equal(t, "RED", value.Name)
+
+	value, exists = enum.ValueOf(color("GREEN"))
+	assert.True(t, exists)
+	assert.Equal(t, "GREEN", value.Name)
+
+	_, exists = enum.ValueOf("RED")
+	assert.False(t, exists)
+	_, exists = enum.ValueOf([]int{3})
+	assert.False(t, exists)
+	assert.Equal(t, "GREEN", enum.Values[1].GoValue())
 }
 
 ///
@@ -595,7 +874,7 @@ func TestInvalidInputNameUndeclared(t *testing.T) {
 		NewSchema().
 			Declare(Input{}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInputNameCharacters(t *testing.T) {
@@ -607,7 +886,7 @@ func TestInvalidInputNameCharacters(t *testing.T) {
 				Name: "InputName!",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInputWithoutFields(t *testing.T) {
@@ -619,7 +898,7 @@ func TestInvalidInputWithoutFields(t *testing.T) {
 				Name: "Test",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInputFieldName(t *testing.T) {
@@ -634,7 +913,24 @@ func TestInvalidInputFieldName(t *testing.T) {
 				}),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestInvalidInputFieldNonNullDefault(t *testing.T) {
+	expected := NewValidationError("Input(Test) Field(TestField) declared with a default value, but its type is non-null")
+
+	actual := CapturePanic(func() {
+		NewSchema().
+			Declare(Input{
+				Name: "Test",
+				Fields: Fields(Field{
+					Name:    "TestField",
+					Type:    NonNullIntType,
+					Default: 10,
+				}),
+			}).Build()
+	})
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInputFieldTypeDoesNotExist(t *testing.T) {
@@ -650,7 +946,7 @@ func TestInvalidInputFieldTypeDoesNotExist(t *testing.T) {
 				}),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInputFieldUnacceptableInterfaceType(t *testing.T) {
@@ -669,7 +965,7 @@ func TestInvalidInputFieldUnacceptableInterfaceType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInputFieldUnacceptableObjectType(t *testing.T) {
@@ -688,7 +984,7 @@ func TestInvalidInputFieldUnacceptableObjectType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInputFieldUnacceptableUnionType(t *testing.T) {
@@ -708,7 +1004,7 @@ func TestInvalidInputFieldUnacceptableUnionType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInputFieldWithArguments(t *testing.T) {
@@ -730,7 +1026,43 @@ func TestInvalidInputFieldWithArguments(t *testing.T) {
 				}),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestInvalidInputFieldWithResolveFunc(t *testing.T) {
+	expected := NewValidationError("Input(Test) Field(TestField) declared with a ResolveFunc. Input fields must be declared without a ResolveFunc")
+
+	actual := CapturePanic(func() {
+		NewSchema().
+			Declare(Input{
+				Name: "Test",
+				Fields: Fields(Field{
+					Name: "TestField",
+					Type: BooleanType,
+					Resolve: func(params ResolveParams) (interface{}, error) {
+						return true, nil
+					},
+				}),
+			}).Build()
+	})
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestInvalidInputFieldNonNullDeprecated(t *testing.T) {
+	expected := NewValidationError("Input(Test) Field(TestField) declared deprecated with a non-null type. Required input fields cannot be deprecated")
+
+	actual := CapturePanic(func() {
+		NewSchema().
+			Declare(Input{
+				Name: "Test",
+				Fields: Fields(Field{
+					Name:              "TestField",
+					Type:              NonNullBooleanType,
+					DeprecationReason: "Unused",
+				}),
+			}).Build()
+	})
+	assert.EqualError(t, actual, expected.Error())
 }
 
 ///
@@ -744,7 +1076,7 @@ func TestInvalidInterfaceNameUndeclared(t *testing.T) {
 		NewSchema().
 			Declare(Interface{}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceNameCharacters(t *testing.T) {
@@ -756,7 +1088,7 @@ func TestInvalidInterfaceNameCharacters(t *testing.T) {
 				Name: "Interface%",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceWithoutFields(t *testing.T) {
@@ -768,7 +1100,7 @@ func TestInvalidInterfaceWithoutFields(t *testing.T) {
 				Name: "Test",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceFieldName(t *testing.T) {
@@ -785,7 +1117,7 @@ func TestInvalidInterfaceFieldName(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceFieldTypeDoesNotExist(t *testing.T) {
@@ -803,7 +1135,7 @@ func TestInvalidInterfaceFieldTypeDoesNotExist(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceFieldTypeUnacceptable(t *testing.T) {
@@ -822,7 +1154,7 @@ func TestInvalidInterfaceFieldTypeUnacceptable(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceFieldArgumentName(t *testing.T) {
@@ -846,7 +1178,7 @@ func TestInvalidInterfaceFieldArgumentName(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceFieldArgumentTypeDoesNotExist(t *testing.T) {
@@ -871,7 +1203,7 @@ func TestInvalidInterfaceFieldArgumentTypeDoesNotExist(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceFieldArgumentUnacceptableInterfaceType(t *testing.T) {
@@ -896,7 +1228,7 @@ func TestInvalidInterfaceFieldArgumentUnacceptableInterfaceType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceFieldArgumentUnacceptableObjectType(t *testing.T) {
@@ -921,7 +1253,7 @@ func TestInvalidInterfaceFieldArgumentUnacceptableObjectType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceFieldArgumentUnacceptableUnionType(t *testing.T) {
@@ -946,7 +1278,7 @@ func TestInvalidInterfaceFieldArgumentUnacceptableUnionType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidInterfaceFieldArgumentNonNullWithDefaultValue(t *testing.T) {
@@ -972,7 +1304,7 @@ func TestInvalidInterfaceFieldArgumentNonNullWithDefaultValue(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 ///
@@ -986,7 +1318,7 @@ func TestInvalidObjectNameUndeclared(t *testing.T) {
 		NewSchema().
 			Declare(Object{}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectNameCharacters(t *testing.T) {
@@ -998,7 +1330,7 @@ func TestInvalidObjectNameCharacters(t *testing.T) {
 				Name: "Object$",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectWithoutFields(t *testing.T) {
@@ -1010,7 +1342,7 @@ func TestInvalidObjectWithoutFields(t *testing.T) {
 				Name: "Test",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectFieldName(t *testing.T) {
@@ -1027,7 +1359,7 @@ func TestInvalidObjectFieldName(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectFieldTypeDoesNotExist(t *testing.T) {
@@ -1045,7 +1377,26 @@ func TestInvalidObjectFieldTypeDoesNotExist(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestInvalidObjectFieldDefault(t *testing.T) {
+	expected := NewValidationError("Object(Test) Field(TestField) declared with a default value. Only Input fields may declare default values")
+
+	actual := CapturePanic(func() {
+		NewSchema().
+			Declare(Object{
+				Name: "Test",
+				Fields: Fields(
+					Field{
+						Name:    "TestField",
+						Type:    IntType,
+						Default: 10,
+					},
+				),
+			}).Build()
+	})
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectFieldTypeUnacceptable(t *testing.T) {
@@ -1064,7 +1415,7 @@ func TestInvalidObjectFieldTypeUnacceptable(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectFieldArgumentName(t *testing.T) {
@@ -1088,7 +1439,7 @@ func TestInvalidObjectFieldArgumentName(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectFieldArgumentTypeDoesNotExist(t *testing.T) {
@@ -1113,7 +1464,7 @@ func TestInvalidObjectFieldArgumentTypeDoesNotExist(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectFieldArgumentUnacceptableInterfaceType(t *testing.T) {
@@ -1138,7 +1489,7 @@ func TestInvalidObjectFieldArgumentUnacceptableInterfaceType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectFieldArgumentUnacceptableObjectType(t *testing.T) {
@@ -1163,7 +1514,7 @@ func TestInvalidObjectFieldArgumentUnacceptableObjectType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectFieldArgumentUnacceptableUnionType(t *testing.T) {
@@ -1188,7 +1539,7 @@ func TestInvalidObjectFieldArgumentUnacceptableUnionType(t *testing.T) {
 				),
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectFieldArgumentNonNullWithDefaultValue(t *testing.T) {
@@ -1214,7 +1565,32 @@ func TestInvalidObjectFieldArgumentNonNullWithDefaultValue(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestInvalidObjectFieldArgumentNonNullDeprecated(t *testing.T) {
+	expected := NewValidationError("Object(Test) Field(TestField) Argument(TestArgument) declared deprecated with a non-null type. Required arguments cannot be deprecated")
+
+	actual := CapturePanic(func() {
+		NewSchema().
+			Declare(Object{
+				Name: "Test",
+				Fields: Fields(
+					Field{
+						Name: "TestField",
+						Type: StringType,
+						Arguments: Arguments(
+							Argument{
+								Name:              "TestArgument",
+								Type:              NonNullStringType,
+								DeprecationReason: "Unused",
+							},
+						),
+					},
+				),
+			}).Build()
+	})
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectImplementsUnknownInterface(t *testing.T) {
@@ -1234,7 +1610,7 @@ func TestInvalidObjectImplementsUnknownInterface(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectInterfaceFieldNotImplemented(t *testing.T) {
@@ -1254,7 +1630,7 @@ func TestInvalidObjectInterfaceFieldNotImplemented(t *testing.T) {
 			}).
 			Declare(TestInterface).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectInterfaceFieldImplementedWithWrongType(t *testing.T) {
@@ -1278,7 +1654,7 @@ func TestInvalidObjectInterfaceFieldImplementedWithWrongType(t *testing.T) {
 			}).
 			Declare(TestInterface).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectInterfaceFieldImplementedWithCorrectTypeButWrongVariant(t *testing.T) {
@@ -1302,7 +1678,7 @@ func TestInvalidObjectInterfaceFieldImplementedWithCorrectTypeButWrongVariant(t
 			}).
 			Declare(TestInterface).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectInterfaceFieldMissingArgument(t *testing.T) {
@@ -1327,7 +1703,7 @@ func TestInvalidObjectInterfaceFieldMissingArgument(t *testing.T) {
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectInterfaceFieldArgumentImplementedWithWrongType(t *testing.T) {
@@ -1358,7 +1734,7 @@ func TestInvalidObjectInterfaceFieldArgumentImplementedWithWrongType(t *testing.
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidObjectImplementsInterfaceFieldWithAdditionalNonNullArgument(t *testing.T) {
@@ -1397,7 +1773,7 @@ func TestInvalidObjectImplementsInterfaceFieldWithAdditionalNonNullArgument(t *t
 			}).
 			Declare(TestInput).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 ///
@@ -1411,7 +1787,7 @@ func TestInvalidScalarNameUndeclared(t *testing.T) {
 		NewSchema().
 			Declare(Scalar{}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidScalarNameCharacters(t *testing.T) {
@@ -1423,7 +1799,22 @@ func TestInvalidScalarNameCharacters(t *testing.T) {
 				Name: "Scalar&",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
+}
+
+func TestInvalidScalarParseValueWithoutParseLiteral(t *testing.T) {
+	expected := NewValidationError("Scalar(Test) declared with a ParseValue function but without a ParseLiteral function")
+
+	actual := CapturePanic(func() {
+		NewSchema().
+			Declare(Scalar{
+				Name: "Test",
+				ParseValue: func(value interface{}) (interface{}, error) {
+					return value, nil
+				},
+			}).Build()
+	})
+	assert.EqualError(t, actual, expected.Error())
 }
 
 ///
@@ -1437,7 +1828,7 @@ func TestInvalidUnionNameUndeclared(t *testing.T) {
 		NewSchema().
 			Declare(Union{}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidUnionNameCharacters(t *testing.T) {
@@ -1449,7 +1840,7 @@ func TestInvalidUnionNameCharacters(t *testing.T) {
 				Name: "Un--ion",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidUnionWithoutMembers(t *testing.T) {
@@ -1461,7 +1852,7 @@ func TestInvalidUnionWithoutMembers(t *testing.T) {
 				Name: "Test",
 			}).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidUnionWithDuplicateMembers(t *testing.T) {
@@ -1475,7 +1866,7 @@ func TestInvalidUnionWithDuplicateMembers(t *testing.T) {
 			}).
 			Declare(TestObject).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidUnionMemberTypeDoesNotExist(t *testing.T) {
@@ -1489,7 +1880,7 @@ func TestInvalidUnionMemberTypeDoesNotExist(t *testing.T) {
 			}).
 			Declare(TestObject).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 func TestInvalidUnionMemberNonObjectMemberType(t *testing.T) {
@@ -1507,7 +1898,7 @@ func TestInvalidUnionMemberNonObjectMemberType(t *testing.T) {
 			Declare(TestObject).
 			Declare(TestScalar).Build()
 	})
-	assert.Equal(t, expected, actual)
+	assert.EqualError(t, actual, expected.Error())
 }
 
 // Capture the panic from function f and return it as an error
@@ -0,0 +1,539 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Names of the __TypeKind values for each TypeKind
+var typeKindNames = map[TypeKind]string{
+	SCALAR:       "SCALAR",
+	OBJECT:       "OBJECT",
+	INTERFACE:    "INTERFACE",
+	UNION:        "UNION",
+	ENUM:         "ENUM",
+	INPUT_OBJECT: "INPUT_OBJECT",
+	LIST:         "LIST",
+}
+
+// typenameMetaField is implicitly defined by every Object, Interface, and
+// Union. Its value is the name of the Object being executed, so it has no
+// ResolveFunc and must be resolved by the executor
+var typenameMetaField = Field{
+	Name:        "__typename",
+	Description: "The name of the current Object type at runtime",
+	Type:        NonNullStringType,
+}
+
+// MetaField returns the introspection meta-field with the given name that the
+// type defines implicitly: __typename on every Object, Interface, and Union,
+// and __schema and __type on the query root type. Returns false if the type
+// does not define a meta-field with that name
+func (schema *Schema) MetaField(declaration Declaration, name string) (Field, bool) {
+	switch declaration.(type) {
+	case Object, Interface, Union:
+	default:
+		return Field{}, false
+	}
+
+	if name == typenameMetaField.Name {
+		return typenameMetaField, true
+	}
+
+	if declaration.GetName() != schema.queryType {
+		return Field{}, false
+	}
+
+	switch name {
+	case "__schema":
+		return Field{
+			Name:        "__schema",
+			Description: "Access the current type schema of this server",
+			Type:        DescribeNonNullType("__Schema"),
+			Resolve: func(params ResolveParams) (interface{}, error) {
+				return schema, nil
+			},
+		}, true
+	case "__type":
+		return Field{
+			Name:        "__type",
+			Description: "Request the type information of a single type",
+			Type:        DescribeType("__Type"),
+			Arguments: Arguments(
+				Argument{
+					Name: "name",
+					Type: NonNullStringType,
+				},
+			),
+			Resolve: func(params ResolveParams) (interface{}, error) {
+				t := DescribeType(params.Arguments["name"].(string))
+				if schema.GetDeclaration(t) == nil {
+					return nil, nil
+				}
+				return introspectedType{schema: schema, t: t}, nil
+			},
+		}, true
+	}
+	return Field{}, false
+}
+
+// declarations returns every Declaration in the Schema sorted by name
+func (schema *Schema) declarations() (declarations []Declaration) {
+	for _, scalar := range schema.scalars {
+		declarations = append(declarations, scalar)
+	}
+	for _, enum := range schema.enums {
+		declarations = append(declarations, enum)
+	}
+	for _, input := range schema.inputs {
+		declarations = append(declarations, input)
+	}
+	for _, intrface := range schema.interfaces {
+		declarations = append(declarations, intrface)
+	}
+	for _, object := range schema.objects {
+		declarations = append(declarations, object)
+	}
+	for _, union := range schema.unions {
+		declarations = append(declarations, union)
+	}
+
+	sort.Slice(declarations, func(i, j int) bool {
+		return declarations[i].GetName() < declarations[j].GetName()
+	})
+	return
+}
+
+///
+// Introspection values - the values resolved for the introspection types
+///
+
+// introspectedType is the value of a __Type. Types wrapped as List or NonNull
+// are unwrapped through ofType
+type introspectedType struct {
+	schema *Schema
+	t      Type
+}
+
+// introspectedField is the value of a __Field
+type introspectedField struct {
+	schema *Schema
+	field  Field
+}
+
+// introspectedDirective is the value of a __Directive
+type introspectedDirective struct {
+	schema    *Schema
+	directive Directive
+}
+
+// introspectedInputValue is the value of an __InputValue
+type introspectedInputValue struct {
+	schema   *Schema
+	argument Argument
+}
+
+// introspectedEnumValue is the value of an __EnumValue
+type introspectedEnumValue struct {
+	value EnumValue
+}
+
+// deprecatable is implemented by the introspection values that may be
+// deprecated
+type deprecatable interface {
+	isDeprecated() bool
+}
+
+func (introspected introspectedField) isDeprecated() bool {
+	return introspected.field.DeprecationReason != ""
+}
+
+func (introspected introspectedInputValue) isDeprecated() bool {
+	return introspected.argument.DeprecationReason != ""
+}
+
+func (introspected introspectedEnumValue) isDeprecated() bool {
+	return introspected.value.DeprecationReason != ""
+}
+
+func (schema *Schema) introspectType(t Type) interface{} {
+	return introspectedType{schema: schema, t: t}
+}
+
+// introspectRootType returns the __Type for a root type, or nil if the Schema
+// does not support the operation type
+func (schema *Schema) introspectRootType(object Object, supported bool) interface{} {
+	if !supported {
+		return nil
+	}
+	return schema.introspectType(DescribeType(object.Name))
+}
+
+func (schema *Schema) introspectFields(fields map[string]Field) []interface{} {
+	introspected := []interface{}{}
+	for _, field := range sortedFields(fields) {
+		introspected = append(introspected, introspectedField{schema: schema, field: field})
+	}
+	return introspected
+}
+
+func (schema *Schema) introspectArguments(arguments map[string]Argument) []interface{} {
+	var names []string
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	introspected := []interface{}{}
+	for _, name := range names {
+		introspected = append(introspected, introspectedInputValue{schema: schema, argument: arguments[name]})
+	}
+	return introspected
+}
+
+func (schema *Schema) introspectTypeNames(names []string) []interface{} {
+	introspected := []interface{}{}
+	for _, name := range names {
+		introspected = append(introspected, schema.introspectType(DescribeType(name)))
+	}
+	return introspected
+}
+
+// nullableString returns nil for an empty string so it is serialized as null
+func nullableString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+///
+// Introspection ResolveFuncs
+///
+
+func resolveSchema(resolve func(schema *Schema) interface{}) ResolveFunc {
+	return func(params ResolveParams) (interface{}, error) {
+		schema, isSchema := params.Source.(*Schema)
+		if !isSchema {
+			return nil, fmt.Errorf("expected a *Schema but found %T", params.Source)
+		}
+		return resolve(schema), nil
+	}
+}
+
+var resolveSchemaTypes = resolveSchema(func(schema *Schema) interface{} {
+	types := []interface{}{}
+	for _, declaration := range schema.declarations() {
+		types = append(types, schema.introspectType(DescribeType(declaration.GetName())))
+	}
+	return types
+})
+
+var resolveSchemaQueryType = resolveSchema(func(schema *Schema) interface{} {
+	return schema.introspectRootType(schema.QueryType())
+})
+
+var resolveSchemaMutationType = resolveSchema(func(schema *Schema) interface{} {
+	return schema.introspectRootType(schema.MutationType())
+})
+
+var resolveSchemaSubscriptionType = resolveSchema(func(schema *Schema) interface{} {
+	return schema.introspectRootType(schema.SubscriptionType())
+})
+
+var resolveSchemaDirectives = resolveSchema(func(schema *Schema) interface{} {
+	directives := []interface{}{}
+	for _, directive := range schema.Directives() {
+		directives = append(directives, introspectedDirective{schema: schema, directive: directive})
+	}
+	return directives
+})
+
+// resolveType returns a ResolveFunc for a __Type field. Fields of named types
+// are given the type's Declaration, and wrapping types are given nil
+func resolveType(resolve func(introspected introspectedType, declaration Declaration) interface{}) ResolveFunc {
+	return func(params ResolveParams) (interface{}, error) {
+		introspected, isType := params.Source.(introspectedType)
+		if !isType {
+			return nil, fmt.Errorf("expected a __Type but found %T", params.Source)
+		}
+
+		var declaration Declaration
+		if !introspected.t.NonNull && !introspected.t.List {
+			declaration = introspected.schema.GetDeclaration(introspected.t)
+		}
+		return resolve(introspected, declaration), nil
+	}
+}
+
+var resolveTypeKind = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	switch {
+	case introspected.t.NonNull:
+		return "NON_NULL"
+	case introspected.t.List:
+		return typeKindNames[LIST]
+	}
+	return typeKindNames[declaration.TypeKind()]
+})
+
+var resolveTypeName = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	if declaration == nil {
+		return nil
+	}
+	return declaration.GetName()
+})
+
+var resolveTypeDescription = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	switch d := declaration.(type) {
+	case Scalar:
+		return nullableString(d.Description)
+	case Enum:
+		return nullableString(d.Description)
+	case Input:
+		return nullableString(d.Description)
+	case Interface:
+		return nullableString(d.Description)
+	case Object:
+		return nullableString(d.Description)
+	case Union:
+		return nullableString(d.Description)
+	}
+	return nil
+})
+
+var resolveTypeSpecifiedByURL = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	if scalar, isScalar := declaration.(Scalar); isScalar {
+		return nullableString(scalar.SpecifiedByURL)
+	}
+	return nil
+})
+
+var resolveTypeFields = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	switch d := declaration.(type) {
+	case Interface:
+		return introspected.schema.introspectFields(d.Fields)
+	case Object:
+		return introspected.schema.introspectFields(d.Fields)
+	}
+	return nil
+})
+
+var resolveTypeInterfaces = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	if object, isObject := declaration.(Object); isObject {
+		return introspected.schema.introspectTypeNames(object.Implements)
+	}
+	return nil
+})
+
+var resolveTypePossibleTypes = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	switch d := declaration.(type) {
+	case Interface:
+		names := introspected.schema.GetObjectsThatImplement(d.Name)
+		sort.Strings(names)
+		return introspected.schema.introspectTypeNames(names)
+	case Union:
+		return introspected.schema.introspectTypeNames(d.Types)
+	}
+	return nil
+})
+
+var resolveTypeEnumValues = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	enum, isEnum := declaration.(Enum)
+	if !isEnum {
+		return nil
+	}
+
+	values := []interface{}{}
+	for _, value := range enum.Values {
+		values = append(values, introspectedEnumValue{value: value})
+	}
+	return values
+})
+
+var resolveTypeInputFields = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	input, isInput := declaration.(Input)
+	if !isInput {
+		return nil
+	}
+
+	arguments := make(map[string]Argument)
+	for name, field := range input.Fields {
+		arguments[name] = Argument{
+			Name:              field.Name,
+			Description:       field.Description,
+			Type:              field.Type,
+			Default:           field.Default,
+			DeprecationReason: field.DeprecationReason,
+		}
+	}
+	return introspected.schema.introspectArguments(arguments)
+})
+
+var resolveTypeOfType = resolveType(func(introspected introspectedType, declaration Declaration) interface{} {
+	switch t := introspected.t; {
+	case t.NonNull:
+		t.NonNull = false
+		return introspected.schema.introspectType(t)
+	case t.List:
+		return introspected.schema.introspectType(*t.SubType)
+	}
+	return nil
+})
+
+func resolveField(resolve func(introspected introspectedField) interface{}) ResolveFunc {
+	return func(params ResolveParams) (interface{}, error) {
+		introspected, isField := params.Source.(introspectedField)
+		if !isField {
+			return nil, fmt.Errorf("expected a __Field but found %T", params.Source)
+		}
+		return resolve(introspected), nil
+	}
+}
+
+var resolveFieldName = resolveField(func(introspected introspectedField) interface{} {
+	return introspected.field.Name
+})
+
+var resolveFieldDescription = resolveField(func(introspected introspectedField) interface{} {
+	return nullableString(introspected.field.Description)
+})
+
+var resolveFieldArgs = resolveField(func(introspected introspectedField) interface{} {
+	return introspected.schema.introspectArguments(introspected.field.Arguments)
+})
+
+var resolveFieldType = resolveField(func(introspected introspectedField) interface{} {
+	return introspected.schema.introspectType(introspected.field.Type)
+})
+
+var resolveFieldIsDeprecated = resolveField(func(introspected introspectedField) interface{} {
+	return introspected.isDeprecated()
+})
+
+var resolveFieldDeprecationReason = resolveField(func(introspected introspectedField) interface{} {
+	return nullableString(introspected.field.DeprecationReason)
+})
+
+func resolveDirective(resolve func(introspected introspectedDirective) interface{}) ResolveFunc {
+	return func(params ResolveParams) (interface{}, error) {
+		introspected, isDirective := params.Source.(introspectedDirective)
+		if !isDirective {
+			return nil, fmt.Errorf("expected a __Directive but found %T", params.Source)
+		}
+		return resolve(introspected), nil
+	}
+}
+
+var resolveDirectiveName = resolveDirective(func(introspected introspectedDirective) interface{} {
+	return introspected.directive.Name
+})
+
+var resolveDirectiveDescription = resolveDirective(func(introspected introspectedDirective) interface{} {
+	return nullableString(introspected.directive.Description)
+})
+
+var resolveDirectiveLocations = resolveDirective(func(introspected introspectedDirective) interface{} {
+	locations := []interface{}{}
+	for _, location := range introspected.directive.Locations {
+		locations = append(locations, string(location))
+	}
+	return locations
+})
+
+var resolveDirectiveArgs = resolveDirective(func(introspected introspectedDirective) interface{} {
+	return introspected.schema.introspectArguments(introspected.directive.Arguments)
+})
+
+var resolveDirectiveIsRepeatable = resolveDirective(func(introspected introspectedDirective) interface{} {
+	return introspected.directive.Repeatable
+})
+
+func resolveInputValue(resolve func(introspected introspectedInputValue) interface{}) ResolveFunc {
+	return func(params ResolveParams) (interface{}, error) {
+		introspected, isInputValue := params.Source.(introspectedInputValue)
+		if !isInputValue {
+			return nil, fmt.Errorf("expected an __InputValue but found %T", params.Source)
+		}
+		return resolve(introspected), nil
+	}
+}
+
+var resolveInputValueName = resolveInputValue(func(introspected introspectedInputValue) interface{} {
+	return introspected.argument.Name
+})
+
+var resolveInputValueDescription = resolveInputValue(func(introspected introspectedInputValue) interface{} {
+	return nullableString(introspected.argument.Description)
+})
+
+var resolveInputValueType = resolveInputValue(func(introspected introspectedInputValue) interface{} {
+	return introspected.schema.introspectType(introspected.argument.Type)
+})
+
+// The default value of an __InputValue is described as a GraphQL value literal
+var resolveInputValueDefaultValue = resolveInputValue(func(introspected introspectedInputValue) interface{} {
+	if introspected.argument.Default == nil {
+		return nil
+	}
+	p := printer{schema: introspected.schema}
+	return p.formatValue(introspected.argument.Default, introspected.argument.Type)
+})
+
+var resolveInputValueIsDeprecated = resolveInputValue(func(introspected introspectedInputValue) interface{} {
+	return introspected.isDeprecated()
+})
+
+var resolveInputValueDeprecationReason = resolveInputValue(func(introspected introspectedInputValue) interface{} {
+	return nullableString(introspected.argument.DeprecationReason)
+})
+
+func resolveEnumValue(resolve func(introspected introspectedEnumValue) interface{}) ResolveFunc {
+	return func(params ResolveParams) (interface{}, error) {
+		introspected, isEnumValue := params.Source.(introspectedEnumValue)
+		if !isEnumValue {
+			return nil, fmt.Errorf("expected an __EnumValue but found %T", params.Source)
+		}
+		return resolve(introspected), nil
+	}
+}
+
+var resolveEnumValueName = resolveEnumValue(func(introspected introspectedEnumValue) interface{} {
+	return introspected.value.Name
+})
+
+var resolveEnumValueDescription = resolveEnumValue(func(introspected introspectedEnumValue) interface{} {
+	return nullableString(introspected.value.Description)
+})
+
+var resolveEnumValueIsDeprecated = resolveEnumValue(func(introspected introspectedEnumValue) interface{} {
+	return introspected.isDeprecated()
+})
+
+var resolveEnumValueDeprecationReason = resolveEnumValue(func(introspected introspectedEnumValue) interface{} {
+	return nullableString(introspected.value.DeprecationReason)
+})
+
+// filterDeprecated wraps the ResolveFunc of a list of deprecatable values,
+// leaving out the deprecated values unless the includeDeprecated argument is
+// true
+func filterDeprecated(resolve ResolveFunc) ResolveFunc {
+	return func(params ResolveParams) (interface{}, error) {
+		value, err := resolve(params)
+		values, isList := value.([]interface{})
+		if err != nil || !isList {
+			return value, err
+		}
+
+		if includeDeprecated, _ := params.Arguments["includeDeprecated"].(bool); includeDeprecated {
+			return values, nil
+		}
+
+		filtered := []interface{}{}
+		for _, value := range values {
+			if introspected, isDeprecatable := value.(deprecatable); !isDeprecatable || !introspected.isDeprecated() {
+				filtered = append(filtered, value)
+			}
+		}
+		return filtered, nil
+	}
+}
@@ -0,0 +1,320 @@
+package schema
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/WilsonGiese/graphql/language"
+)
+
+// Directives every Schema declares, which are omitted from printed SDL
+var builtInDirectives = map[string]struct{}{
+	"skip":        struct{}{},
+	"include":     struct{}{},
+	"deprecated":  struct{}{},
+	"specifiedBy": struct{}{},
+}
+
+// PrintSDL returns the Schema described in the GraphQL schema definition
+// language. Types are printed in order of their names, and Fields and Arguments
+// in order of theirs, so the same Schema always prints the same document.
+// Directives are printed after the types in order of their names. Built-in
+// Scalars, Directives, and introspection types are omitted
+func PrintSDL(schema *Schema) string {
+	var buffer bytes.Buffer
+	WriteSDL(&buffer, schema)
+	return buffer.String()
+}
+
+// WriteSDL writes the Schema described in the GraphQL schema definition
+// language to w. See PrintSDL for details. Returns any error returned by w
+func WriteSDL(w io.Writer, schema *Schema) error {
+	p := printer{schema: schema}
+	p.printSchema()
+	_, err := w.Write(p.buffer.Bytes())
+	return err
+}
+
+type printer struct {
+	schema *Schema
+	buffer bytes.Buffer
+}
+
+func (p *printer) printf(format string, s ...interface{}) {
+	fmt.Fprintf(&p.buffer, format, s...)
+}
+
+func (p *printer) printSchema() {
+	var definitions []func()
+
+	// The schema definition may be omitted only if every root type uses its
+	// conventional name, and finding the root types by their default names, as
+	// a Schema built without root type names does, finds the same root types.
+	// Otherwise an Object that is not a root type, but is named like one, would
+	// become a root type when the printed schema is parsed
+	rootTypes := []struct {
+		operationType, name string
+		defaultNames        []string
+	}{
+		{"query", p.schema.queryType, defaultQueryTypeNames},
+		{"mutation", p.schema.mutationType, defaultMutationTypeNames},
+		{"subscription", p.schema.subscriptionType, defaultSubscriptionTypeNames},
+	}
+	foundByDefault := true
+	for _, rootType := range rootTypes {
+		conventional := rootType.name == "" || rootType.name == rootType.defaultNames[0]
+		foundByDefault = foundByDefault && conventional && rootType.name == p.schema.findRootType(rootType.defaultNames)
+	}
+	if !foundByDefault {
+		definitions = append(definitions, func() {
+			p.printf("schema {\n")
+			for _, rootType := range rootTypes {
+				if rootType.name != "" {
+					p.printf("  %s: %s\n", rootType.operationType, rootType.name)
+				}
+			}
+			p.printf("}\n")
+		})
+	}
+
+	for _, declaration := range p.declarations() {
+		declaration := declaration
+		definitions = append(definitions, func() {
+			p.printDeclaration(declaration)
+		})
+	}
+
+	for _, directive := range p.schema.Directives() {
+		if _, builtIn := builtInDirectives[directive.Name]; builtIn {
+			continue
+		}
+
+		directive := directive
+		definitions = append(definitions, func() {
+			p.printDirective(directive)
+		})
+	}
+
+	for i, definition := range definitions {
+		if i > 0 {
+			p.printf("\n")
+		}
+		definition()
+	}
+}
+
+// declarations returns every Declaration to be printed sorted by name
+func (p *printer) declarations() (printed []Declaration) {
+	for _, declaration := range p.schema.declarations() {
+		_, builtIn := builtInScalars[declaration.GetName()]
+		if !builtIn && !strings.HasPrefix(declaration.GetName(), "__") {
+			printed = append(printed, declaration)
+		}
+	}
+	return
+}
+
+func (p *printer) printDeclaration(declaration Declaration) {
+	switch d := declaration.(type) {
+	case Scalar:
+		p.printDescription(d.Description, "")
+		p.printf("scalar %s", d.Name)
+		if d.SpecifiedByURL != "" {
+			p.printf(" @specifiedBy(url: %s)", language.QuoteString(d.SpecifiedByURL))
+		}
+		p.printf("\n")
+	case Enum:
+		p.printDescription(d.Description, "")
+		p.printf("enum %s {\n", d.Name)
+		for _, value := range d.Values {
+			p.printDescription(value.Description, "  ")
+			p.printf("  %s", value.Name)
+			p.printDeprecated(value.DeprecationReason)
+			p.printf("\n")
+		}
+		p.printf("}\n")
+	case Input:
+		p.printDescription(d.Description, "")
+		p.printf("input %s {\n", d.Name)
+		for _, field := range sortedFields(d.Fields) {
+			p.printDescription(field.Description, "  ")
+			p.printf("  %s: %s", field.Name, field.Type)
+			if field.Default != nil {
+				p.printf(" = %s", p.formatValue(field.Default, field.Type))
+			}
+			p.printDeprecated(field.DeprecationReason)
+			p.printf("\n")
+		}
+		p.printf("}\n")
+	case Interface:
+		p.printDescription(d.Description, "")
+		p.printf("interface %s {\n", d.Name)
+		p.printFields(d.Fields)
+		p.printf("}\n")
+	case Object:
+		p.printDescription(d.Description, "")
+		p.printf("type %s", d.Name)
+		if len(d.Implements) > 0 {
+			p.printf(" implements %s", strings.Join(d.Implements, " & "))
+		}
+		p.printf(" {\n")
+		p.printFields(d.Fields)
+		p.printf("}\n")
+	case Union:
+		p.printDescription(d.Description, "")
+		p.printf("union %s = %s\n", d.Name, strings.Join(d.Types, " | "))
+	}
+}
+
+func (p *printer) printDirective(directive Directive) {
+	p.printDescription(directive.Description, "")
+	p.printf("directive @%s", directive.Name)
+	p.printArguments(directive.Arguments, "")
+	if directive.Repeatable {
+		p.printf(" repeatable")
+	}
+
+	locations := make([]string, len(directive.Locations))
+	for i, location := range directive.Locations {
+		locations[i] = string(location)
+	}
+	p.printf(" on %s\n", strings.Join(locations, " | "))
+}
+
+func (p *printer) printFields(fields map[string]Field) {
+	for _, field := range sortedFields(fields) {
+		p.printDescription(field.Description, "  ")
+		p.printf("  %s", field.Name)
+		p.printArguments(field.Arguments, "  ")
+		p.printf(": %s", field.Type)
+		p.printDeprecated(field.DeprecationReason)
+		p.printf("\n")
+	}
+}
+
+// printArguments prints an ArgumentsDefinition of a definition printed at the
+// given indentation. Arguments are printed on a single line unless one of them
+// has a description
+func (p *printer) printArguments(arguments map[string]Argument, indent string) {
+	if len(arguments) == 0 {
+		return
+	}
+
+	var names []string
+	described := false
+	for name, argument := range arguments {
+		names = append(names, name)
+		described = described || argument.Description != ""
+	}
+	sort.Strings(names)
+
+	p.printf("(")
+	for i, name := range names {
+		argument := arguments[name]
+
+		if described {
+			p.printf("\n")
+			p.printDescription(argument.Description, indent+"  ")
+			p.printf("%s  ", indent)
+		} else if i > 0 {
+			p.printf(", ")
+		}
+
+		p.printf("%s: %s", argument.Name, argument.Type)
+		if argument.Default != nil {
+			p.printf(" = %s", p.formatValue(argument.Default, argument.Type))
+		}
+		p.printDeprecated(argument.DeprecationReason)
+	}
+
+	if described {
+		p.printf("\n%s", indent)
+	}
+	p.printf(")")
+}
+
+// printDeprecated prints the @deprecated directive of a deprecated definition.
+// The reason is left out if it is the default reason
+func (p *printer) printDeprecated(reason string) {
+	switch reason {
+	case "":
+	case DefaultDeprecationReason:
+		p.printf(" @deprecated")
+	default:
+		p.printf(" @deprecated(reason: %s)", language.QuoteString(reason))
+	}
+}
+
+// printDescription prints a description as a String, or as a BlockString if
+// it spans multiple lines
+func (p *printer) printDescription(description, indent string) {
+	if description == "" {
+		return
+	}
+
+	if !strings.Contains(description, "\n") {
+		p.printf("%s%s\n", indent, language.QuoteString(description))
+		return
+	}
+
+	p.printf("%s\"\"\"\n", indent)
+	for _, line := range strings.Split(description, "\n") {
+		if line == "" {
+			p.printf("\n")
+		} else {
+			p.printf("%s%s\n", indent, strings.Replace(line, `"""`, `\"""`, -1))
+		}
+	}
+	p.printf("%s\"\"\"\n", indent)
+}
+
+// formatValue formats a Go value as a GraphQL value literal of type t
+func (p *printer) formatValue(value interface{}, t Type) string {
+	if value == nil {
+		return "null"
+	}
+
+	// Enum values are formatted as the names their Go values map to
+	if enum, err := p.schema.getEnum(t.Name); err == nil {
+		if enumValue, exists := enum.ValueOf(value); exists {
+			return enumValue.Name
+		}
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		itemType := t
+		if t.List && t.SubType != nil {
+			itemType = *t.SubType
+		}
+
+		items := make([]string, v.Len())
+		for i := range items {
+			items[i] = p.formatValue(v.Index(i).Interface(), itemType)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case reflect.Map:
+		input, _ := p.schema.getInput(t.Name)
+
+		var keys []string
+		for _, key := range v.MapKeys() {
+			keys = append(keys, fmt.Sprint(key.Interface()))
+		}
+		sort.Strings(keys)
+
+		fields := make([]string, len(keys))
+		for i, key := range keys {
+			fieldValue := v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+			fields[i] = key + ": " + p.formatValue(fieldValue.Interface(), input.Fields[key].Type)
+		}
+		return "{" + strings.Join(fields, ", ") + "}"
+	case reflect.String:
+		return language.QuoteString(v.String())
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,214 @@
+package schema
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintSDL(t *testing.T) {
+	schema := NewSchema().
+		Declare(Scalar{
+			Name:           "Time",
+			Description:    "An ISO 8601 timestamp",
+			SpecifiedByURL: "https://www.iso.org/iso-8601-date-and-time-format.html",
+		}).
+		Declare(Enum{
+			Name:        "DogCommand",
+			Description: "Commands that a Dog may know",
+			Values: []EnumValue{
+				{Name: "SIT", Description: "Sit down"},
+				{Name: "DOWN"},
+				{Name: "HEEL", DeprecationReason: "Dogs no longer heel"},
+			},
+		}).
+		Declare(Input{
+			Name: "DogFilter",
+			Fields: Fields(
+				Field{
+					Name:              "name",
+					Type:              StringType,
+					DeprecationReason: DefaultDeprecationReason,
+				},
+				Field{
+					Name:        "commands",
+					Description: "Commands the Dog must \"know\"",
+					Type:        DescribeListType(DescribeNonNullType("DogCommand")),
+				},
+			),
+		}).
+		Declare(Interface{
+			Name: "Pet",
+			Fields: Fields(
+				Field{
+					Name: "name",
+					Type: NonNullStringType,
+				},
+			),
+		}).
+		Declare(Object{
+			Name:        "Dog",
+			Description: "Pet type Dog",
+			Implements:  Interfaces("Pet"),
+			Fields: Fields(
+				Field{
+					Name:        "name",
+					Description: "Name of this Dog",
+					Type:        NonNullStringType,
+				},
+				Field{
+					Name: "doesKnowCommand",
+					Type: NonNullBooleanType,
+					Arguments: Arguments(
+						Argument{
+							Name:        "dogCommand",
+							Description: "The command to check",
+							Type:        DescribeType("DogCommand"),
+							Default:     "SIT",
+						},
+					),
+				},
+				Field{
+					Name: "barkVolume",
+					Type: IntType,
+					Arguments: Arguments(
+						Argument{
+							Name:    "scale",
+							Type:    FloatType,
+							Default: 1.5,
+						},
+						Argument{
+							Name:              "unit",
+							Type:              StringType,
+							Default:           "decibels",
+							DeprecationReason: "Always decibels",
+						},
+					),
+					DeprecationReason: DefaultDeprecationReason,
+				},
+				Field{
+					Name: "born",
+					Type: DescribeType("Time"),
+				},
+			),
+		}).
+		Declare(Object{
+			Name:        "Cat",
+			Description: "Pet type Cat\n\nCats do not know any \"\"\"commands\"\"\"",
+			Implements:  Interfaces("Pet"),
+			Fields: Fields(
+				Field{
+					Name: "name",
+					Type: NonNullStringType,
+				},
+			),
+		}).
+		Declare(Union{
+			Name:  "CatOrDog",
+			Types: Types("Cat", "Dog"),
+		}).
+		Declare(Object{
+			Name: "QueryRoot",
+			Fields: Fields(
+				Field{
+					Name: "dogs",
+					Type: DescribeNonNullListType(DescribeType("Dog")),
+					Arguments: Arguments(
+						Argument{
+							Name:    "filter",
+							Type:    DescribeType("DogFilter"),
+							Default: map[string]interface{}{"commands": []interface{}{"SIT"}, "name": "Rex"},
+						},
+					),
+				},
+				Field{
+					Name: "pets",
+					Type: DescribeListType(DescribeType("CatOrDog")),
+				},
+			),
+		}).
+		Directive(Directive{
+			Name:        "cached",
+			Description: "Caches the value of a field",
+			Arguments: Arguments(
+				Argument{
+					Name:        "seconds",
+					Description: "How long the value is cached for",
+					Type:        IntType,
+					Default:     60,
+				},
+			),
+			Repeatable: true,
+			Locations:  Locations(LocationFieldDefinition, LocationObject),
+		}).Build()
+
+	expected := `schema {
+  query: QueryRoot
+}
+
+"""
+Pet type Cat
+
+Cats do not know any \"""commands\"""
+"""
+type Cat implements Pet {
+  name: String!
+}
+
+union CatOrDog = Cat | Dog
+
+"Pet type Dog"
+type Dog implements Pet {
+  barkVolume(scale: Float = 1.5, unit: String = "decibels" @deprecated(reason: "Always decibels")): Int @deprecated
+  born: Time
+  doesKnowCommand(
+    "The command to check"
+    dogCommand: DogCommand = SIT
+  ): Boolean!
+  "Name of this Dog"
+  name: String!
+}
+
+"Commands that a Dog may know"
+enum DogCommand {
+  "Sit down"
+  SIT
+  DOWN
+  HEEL @deprecated(reason: "Dogs no longer heel")
+}
+
+input DogFilter {
+  "Commands the Dog must \"know\""
+  commands: [DogCommand!]
+  name: String @deprecated
+}
+
+interface Pet {
+  name: String!
+}
+
+type QueryRoot {
+  dogs(filter: DogFilter = {commands: [SIT], name: "Rex"}): [Dog]!
+  pets: [CatOrDog]
+}
+
+"An ISO 8601 timestamp"
+scalar Time @specifiedBy(url: "https://www.iso.org/iso-8601-date-and-time-format.html")
+
+"Caches the value of a field"
+directive @cached(
+  "How long the value is cached for"
+  seconds: Int = 60
+) repeatable on FIELD_DEFINITION | OBJECT
+`
+	assert.Equal(t, expected, PrintSDL(schema))
+
+	var buffer bytes.Buffer
+	assert.Nil(t, WriteSDL(&buffer, schema))
+	assert.Equal(t, expected, buffer.String())
+}
+
+func TestPrintSDLDefaultSchema(t *testing.T) {
+	assert.Equal(t, "", PrintSDL(NewSchema().Build()))
+}
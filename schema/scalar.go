@@ -0,0 +1,264 @@
+package schema
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/WilsonGiese/graphql/language"
+)
+
+// CoerceFunc converts a value to or from the Go value of a Scalar. Returns an
+// error if the value cannot be represented by the Scalar
+type CoerceFunc func(value interface{}) (interface{}, error)
+
+// EnumLiteral is an enum value literal, such as RED, given to the ParseLiteral
+// function of a Scalar
+type EnumLiteral string
+
+///
+// Built-in Scalars - declared by every Schema
+///
+
+// IntScalar represents a signed 32-bit integer
+var IntScalar = Scalar{
+	Name:         "Int",
+	Description:  "The Int scalar type represents a signed 32‐bit numeric non‐fractional value. Response formats that support a 32‐bit integer or a number type should use that type to represent this scalar.",
+	Serialize:    coerceInt,
+	ParseValue:   coerceInt,
+	ParseLiteral: parseIntLiteral,
+}
+
+// FloatScalar represents a double-precision floating point number
+var FloatScalar = Scalar{
+	Name:         "Float",
+	Description:  "The Float scalar type represents signed double‐precision fractional values as specified by IEEE 754. Response formats that support an appropriate double‐precision number type should use that type to represent this scalar.",
+	Serialize:    coerceFloat,
+	ParseValue:   coerceFloat,
+	ParseLiteral: parseFloatLiteral,
+}
+
+// StringScalar represents UTF-8 text
+var StringScalar = Scalar{
+	Name:         "String",
+	Description:  "The String scalar type represents textual data, represented as UTF‐8 character sequences. The String type is most often used by GraphQL to represent free‐form human‐readable text. All response formats must support string representations, and that representation must be used here.",
+	Serialize:    serializeString,
+	ParseValue:   parseStringValue,
+	ParseLiteral: parseStringValue,
+}
+
+// BooleanScalar represents true or false
+var BooleanScalar = Scalar{
+	Name:         "Boolean",
+	Description:  "The Boolean scalar type represents true or false. Response formats should use a built‐in boolean type if supported; otherwise, they should use their representation of the integers 1 and 0.",
+	Serialize:    serializeBoolean,
+	ParseValue:   parseBooleanValue,
+	ParseLiteral: parseBooleanValue,
+}
+
+// IDScalar represents a unique identifier, which is always serialized as a
+// string
+var IDScalar = Scalar{
+	Name:         "ID",
+	Description:  "The ID scalar type represents a unique identifier, often used to refetch an object or as the key for a cache. The ID type is serialized in the same way as a String; however, it is not intended to be human‐readable. While it is often numeric, it should always serialize as a String.",
+	Serialize:    coerceID,
+	ParseValue:   coerceID,
+	ParseLiteral: parseIDLiteral,
+}
+
+// builtInScalars are the Scalars every Schema declares, which schema definition
+// documents use without defining them and which are omitted from printed SDL
+var builtInScalars = map[string]Scalar{
+	IntScalar.Name:     IntScalar,
+	FloatScalar.Name:   FloatScalar,
+	StringScalar.Name:  StringScalar,
+	BooleanScalar.Name: BooleanScalar,
+	IDScalar.Name:      IDScalar,
+}
+
+// coerceInt accepts integers, and floats without a fractional part since JSON
+// decodes every number as a float64
+func coerceInt(value interface{}) (interface{}, error) {
+	if i, ok := toInt32(value); ok {
+		return i, nil
+	}
+	return nil, fmt.Errorf("Int cannot represent %v", value)
+}
+
+func parseIntLiteral(literal interface{}) (interface{}, error) {
+	if i, isInt := literal.(int64); isInt && i >= math.MinInt32 && i <= math.MaxInt32 {
+		return int(i), nil
+	}
+	return nil, fmt.Errorf("Int cannot represent %v", literal)
+}
+
+// coerceFloat accepts any finite Go number
+func coerceFloat(value interface{}) (interface{}, error) {
+	if f, ok := toFloat(value); ok && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f, nil
+	}
+	return nil, fmt.Errorf("Float cannot represent %v", value)
+}
+
+func parseFloatLiteral(literal interface{}) (interface{}, error) {
+	switch f := literal.(type) {
+	case int64:
+		return float64(f), nil
+	case float64:
+		return f, nil
+	}
+	return nil, fmt.Errorf("Float cannot represent %v", literal)
+}
+
+// serializeString accepts strings, and formats booleans and numbers as strings
+func serializeString(value interface{}) (interface{}, error) {
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		return fmt.Sprint(value), nil
+	}
+	return nil, fmt.Errorf("String cannot represent %v", value)
+}
+
+func parseStringValue(value interface{}) (interface{}, error) {
+	if s, isString := value.(string); isString {
+		return s, nil
+	}
+	return nil, fmt.Errorf("String cannot represent a non-string value: %v", value)
+}
+
+func serializeBoolean(value interface{}) (interface{}, error) {
+	if v := reflect.ValueOf(value); v.Kind() == reflect.Bool {
+		return v.Bool(), nil
+	}
+	return nil, fmt.Errorf("Boolean cannot represent %v", value)
+}
+
+func parseBooleanValue(value interface{}) (interface{}, error) {
+	if b, isBool := value.(bool); isBool {
+		return b, nil
+	}
+	return nil, fmt.Errorf("Boolean cannot represent a non-boolean value: %v", value)
+}
+
+// coerceID accepts strings and integers, which are formatted as strings
+func coerceID(value interface{}) (interface{}, error) {
+	if v := reflect.ValueOf(value); v.Kind() == reflect.String {
+		return v.String(), nil
+	}
+	if i, ok := toInteger(value); ok {
+		return strconv.FormatInt(i, 10), nil
+	}
+	return nil, fmt.Errorf("ID cannot represent %v", value)
+}
+
+func parseIDLiteral(literal interface{}) (interface{}, error) {
+	switch id := literal.(type) {
+	case string:
+		return id, nil
+	case int64:
+		return strconv.FormatInt(id, 10), nil
+	}
+	return nil, fmt.Errorf("ID cannot represent %v", literal)
+}
+
+///
+// Number Conversion Helpers
+///
+
+// toInteger converts any Go integer, or a float without a fractional part, to
+// an int64
+func toInteger(value interface{}) (int64, bool) {
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() <= math.MaxInt64 {
+			return int64(v.Uint()), true
+		}
+	case reflect.Float32, reflect.Float64:
+		if f := v.Float(); f == math.Trunc(f) && f >= math.MinInt64 && f <= math.MaxInt64 {
+			return int64(f), true
+		}
+	}
+	return 0, false
+}
+
+// toInt32 converts a value to an int if it is an integer within the signed
+// 32-bit range required by the Int scalar
+func toInt32(value interface{}) (int, bool) {
+	if i, ok := toInteger(value); ok && i >= math.MinInt32 && i <= math.MaxInt32 {
+		return int(i), true
+	}
+	return 0, false
+}
+
+// toFloat converts any Go integer or float to a float64
+func toFloat(value interface{}) (float64, bool) {
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// ParseScalarLiteral parses a literal from a Document as a value of the
+// Scalar. Variables within list and object literals are replaced by their
+// values
+func ParseScalarLiteral(scalar Scalar, literal language.Value, variables map[string]interface{}) (interface{}, error) {
+	value := literalValue(literal, variables)
+	if scalar.ParseLiteral != nil {
+		return scalar.ParseLiteral(value)
+	}
+
+	// Scalars without a ParseLiteral function accept any literal that is not a
+	// list or object
+	switch v := value.(type) {
+	case int64:
+		return int(v), nil
+	case float64, string, bool:
+		return v, nil
+	case EnumLiteral:
+		return string(v), nil
+	}
+	return nil, fmt.Errorf("expected value of type '%s' but found %s", scalar.Name, literal)
+}
+
+// literalValue converts a literal from a Document to the Go value given to the
+// ParseLiteral function of a scalar
+func literalValue(literal language.Value, variables map[string]interface{}) interface{} {
+	switch v := literal.(type) {
+	case language.IntValue:
+		return v.Value
+	case language.FloatValue:
+		return v.Value
+	case language.StringValue:
+		return v.Value
+	case language.BooleanValue:
+		return v.Value
+	case language.EnumValue:
+		return EnumLiteral(v.Value)
+	case language.Variable:
+		return variables[v.Name]
+	case language.ListValue:
+		list := make([]interface{}, len(v.Values))
+		for i, item := range v.Values {
+			list[i] = literalValue(item, variables)
+		}
+		return list
+	case language.ObjectValue:
+		object := make(map[string]interface{})
+		for name, field := range v.Fields {
+			object[name] = literalValue(field.Value, variables)
+		}
+		return object
+	}
+	return nil
+}
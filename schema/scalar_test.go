@@ -0,0 +1,78 @@
+package schema
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type ScalarTest struct {
+	coerce   CoerceFunc
+	value    interface{}
+	expected interface{} // nil if the value cannot be coerced
+}
+
+type namedString string
+
+var scalarTests = []ScalarTest{
+	// Int
+	{IntScalar.Serialize, 42, 42},
+	{IntScalar.Serialize, int64(math.MaxInt32), math.MaxInt32},
+	{IntScalar.Serialize, int64(math.MaxInt32) + 1, nil},
+	{IntScalar.Serialize, 1.0, 1},
+	{IntScalar.Serialize, 1.5, nil},
+	{IntScalar.Serialize, "1", nil},
+	{IntScalar.ParseValue, float64(7), 7},
+	{IntScalar.ParseValue, true, nil},
+	{IntScalar.ParseLiteral, int64(-3), -3},
+	{IntScalar.ParseLiteral, int64(math.MinInt32) - 1, nil},
+	{IntScalar.ParseLiteral, 3.0, nil},
+
+	// Float
+	{FloatScalar.Serialize, 1.5, 1.5},
+	{FloatScalar.Serialize, uint8(2), 2.0},
+	{FloatScalar.Serialize, math.Inf(1), nil},
+	{FloatScalar.Serialize, math.NaN(), nil},
+	{FloatScalar.ParseValue, "1.5", nil},
+	{FloatScalar.ParseLiteral, int64(2), 2.0},
+	{FloatScalar.ParseLiteral, 2.5, 2.5},
+	{FloatScalar.ParseLiteral, "2.5", nil},
+
+	// String
+	{StringScalar.Serialize, "text", "text"},
+	{StringScalar.Serialize, namedString("named"), "named"},
+	{StringScalar.Serialize, 1.5, "1.5"},
+	{StringScalar.Serialize, true, "true"},
+	{StringScalar.Serialize, []string{"list"}, nil},
+	{StringScalar.ParseValue, 1, nil},
+	{StringScalar.ParseLiteral, "text", "text"},
+	{StringScalar.ParseLiteral, EnumLiteral("TEXT"), nil},
+
+	// Boolean
+	{BooleanScalar.Serialize, false, false},
+	{BooleanScalar.Serialize, 0, nil},
+	{BooleanScalar.ParseValue, true, true},
+	{BooleanScalar.ParseLiteral, "true", nil},
+
+	// ID
+	{IDScalar.Serialize, "abc", "abc"},
+	{IDScalar.Serialize, 12, "12"},
+	{IDScalar.Serialize, 1.5, nil},
+	{IDScalar.ParseValue, float64(12), "12"},
+	{IDScalar.ParseLiteral, int64(12), "12"},
+	{IDScalar.ParseLiteral, "abc", "abc"},
+	{IDScalar.ParseLiteral, 1.0, nil},
+}
+
+func TestBuiltInScalars(t *testing.T) {
+	for _, test := range scalarTests {
+		actual, err := test.coerce(test.value)
+		if test.expected == nil {
+			assert.Error(t, err, "%T(%v)", test.value, test.value)
+		} else {
+			assert.NoError(t, err, "%T(%v)", test.value, test.value)
+			assert.Equal(t, test.expected, actual, "%T(%v)", test.value, test.value)
+		}
+	}
+}
@@ -1,8 +1,14 @@
 package schema
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/WilsonGiese/graphql/language"
 )
 
 // Schema describes the structure and behavior of a GraphQL service
@@ -13,6 +19,14 @@ type Schema struct {
 	objects    map[string]Object
 	scalars    map[string]Scalar
 	unions     map[string]Union
+
+	// Directives that may be used in documents and schema definitions
+	directives map[string]Directive
+
+	// Names of the root Object types for each type of operation
+	queryType        string
+	mutationType     string
+	subscriptionType string
 }
 
 func newSchema() *Schema {
@@ -23,7 +37,28 @@ func newSchema() *Schema {
 		objects:    make(map[string]Object),
 		scalars:    make(map[string]Scalar),
 		unions:     make(map[string]Union),
+		directives: make(map[string]Directive),
+	}
+}
+
+// GetDirective returns the Directive with the given name. Returns false if the
+// Schema does not declare a Directive with that name
+func (schema *Schema) GetDirective(name string) (Directive, bool) {
+	directive, exists := schema.directives[name]
+	return directive, exists
+}
+
+// Directives returns every Directive declared by the Schema, including the
+// built-in Directives, sorted by name
+func (schema *Schema) Directives() []Directive {
+	directives := make([]Directive, 0, len(schema.directives))
+	for _, directive := range schema.directives {
+		directives = append(directives, directive)
 	}
+	sort.Slice(directives, func(i, j int) bool {
+		return directives[i].Name < directives[j].Name
+	})
+	return directives
 }
 
 // GetObjectsThatImplement returns a list of Object type names that implement
@@ -38,6 +73,51 @@ func (schema *Schema) GetObjectsThatImplement(interfaceName string) (objectNames
 	return
 }
 
+// QueryType returns the root Object type for query operations. Returns false
+// if the Schema does not declare a query root type
+func (schema *Schema) QueryType() (Object, bool) {
+	return schema.getRootType(schema.queryType)
+}
+
+// MutationType returns the root Object type for mutation operations. Returns
+// false if the Schema does not support mutations
+func (schema *Schema) MutationType() (Object, bool) {
+	return schema.getRootType(schema.mutationType)
+}
+
+// SubscriptionType returns the root Object type for subscription operations.
+// Returns false if the Schema does not support subscriptions
+func (schema *Schema) SubscriptionType() (Object, bool) {
+	return schema.getRootType(schema.subscriptionType)
+}
+
+// Names root types are found by, in order of preference, when a Schema is built
+// without any root type names set
+var (
+	defaultQueryTypeNames        = []string{"Query", "QueryRoot"}
+	defaultMutationTypeNames     = []string{"Mutation", "MutationRoot"}
+	defaultSubscriptionTypeNames = []string{"Subscription", "SubscriptionRoot"}
+)
+
+// findRootType returns the first of the names that is the name of a declared
+// Object, or an empty string if none are
+func (schema *Schema) findRootType(names []string) string {
+	for _, name := range names {
+		if _, err := schema.getObject(name); err == nil {
+			return name
+		}
+	}
+	return ""
+}
+
+func (schema *Schema) getRootType(name string) (Object, bool) {
+	if name == "" {
+		return Object{}, false
+	}
+	object, err := schema.getObject(name)
+	return object, err == nil
+}
+
 // Declaration represents a declared Type in the GraphQL Schema
 type Declaration interface {
 	GetName() string
@@ -62,10 +142,29 @@ const (
 // Schema Types
 ///
 
-// Scalar describes a scalar type within a Schema
+// Scalar describes a scalar type within a Schema. The coercion functions of a
+// Scalar declared without them pass values through unchanged, and accept any
+// literal other than a list or object
 type Scalar struct {
 	Name        string
 	Description string
+
+	// SpecifiedByURL is the URL of a specification of the Scalar's behavior,
+	// such as the format of its values. Built-in Scalars have none
+	SpecifiedByURL string
+
+	// Serialize converts a value resolved for the Scalar to its representation
+	// in a response
+	Serialize CoerceFunc
+
+	// ParseValue converts a value provided for a variable, such as a value
+	// decoded from JSON, to the Go value of the Scalar
+	ParseValue CoerceFunc
+
+	// ParseLiteral converts a literal from a document to the Go value of the
+	// Scalar. Literals are given as an int64, float64, string, bool,
+	// EnumLiteral, []interface{}, or map[string]interface{}
+	ParseLiteral CoerceFunc
 }
 
 func (scalar Scalar) GetName() string {
@@ -77,10 +176,14 @@ func (scalar Scalar) TypeKind() TypeKind {
 	return SCALAR
 }
 
+func (scalar Scalar) String() string {
+	return fmt.Sprintf("Scalar(%s)", scalar.Name)
+}
+
 // Enum describes an enum type within a Schema
 type Enum struct {
 	Name        string
-	Values      []string
+	Values      []EnumValue
 	Description string
 }
 
@@ -97,6 +200,61 @@ func (enum Enum) String() string {
 	return fmt.Sprintf("Enum(%s)", enum.Name)
 }
 
+// GetValue returns the EnumValue with the given name. Returns false if the
+// Enum does not declare a value with that name
+func (enum Enum) GetValue(name string) (EnumValue, bool) {
+	for _, value := range enum.Values {
+		if value.Name == name {
+			return value, true
+		}
+	}
+	return EnumValue{}, false
+}
+
+// ValueOf returns the EnumValue that a Go value resolved for the Enum maps to.
+// A value without a Go value is also mapped to by a string of its name.
+// Returns false if no EnumValue maps to the Go value
+func (enum Enum) ValueOf(goValue interface{}) (EnumValue, bool) {
+	if goValue == nil || !reflect.TypeOf(goValue).Comparable() {
+		return EnumValue{}, false
+	}
+
+	for _, value := range enum.Values {
+		if value.Value != nil && reflect.TypeOf(value.Value).Comparable() && value.Value == goValue {
+			return value, true
+		}
+	}
+
+	if v := reflect.ValueOf(goValue); v.Kind() == reflect.String {
+		if value, exists := enum.GetValue(v.String()); exists && value.Value == nil {
+			return value, true
+		}
+	}
+	return EnumValue{}, false
+}
+
+// EnumValue describes a value of an Enum. Clients see the value's Name, while
+// resolvers are given, and return, its Go Value instead. The Name is used as
+// the Go value of an EnumValue declared without a Value
+type EnumValue struct {
+	Name              string
+	Description       string
+	Value             interface{}
+	DeprecationReason string // Reason the EnumValue is deprecated for; empty if it is not deprecated
+}
+
+// GoValue returns the Go value of the EnumValue
+func (value EnumValue) GoValue() interface{} {
+	if value.Value == nil {
+		return value.Name
+	}
+	return value.Value
+}
+
+func (value EnumValue) String() string {
+	return fmt.Sprintf("EnumValue(%s)", value.Name)
+}
+
 // Input describes an input type object within a Schema
 type Input struct {
 	Name        string
@@ -122,6 +280,7 @@ type Interface struct {
 	Name        string
 	Description string
 	Fields      map[string]Field
+	ResolveType ResolveTypeFunc
 }
 
 func (intrface Interface) GetName() string {
@@ -142,6 +301,7 @@ type Union struct {
 	Name        string
 	Description string
 	Types       []string
+	ResolveType ResolveTypeFunc
 }
 
 func (union Union) GetName() string {
@@ -189,16 +349,47 @@ func (object Object) ImplementsInterface(interfaceName string) bool {
 
 // Field describes a Field for a Type defined within a Schema
 type Field struct {
-	Name        string
-	Description string
-	Type        Type
-	Arguments   map[string]Argument
+	Name              string
+	Description       string
+	Type              Type
+	Arguments         map[string]Argument
+	Resolve           ResolveFunc
+	Default           interface{} // Value of an Input field that is not provided; only Input fields may have one
+	DeprecationReason string      // Reason the Field is deprecated for; empty if it is not deprecated
 }
 
 func (field Field) String() string {
 	return fmt.Sprintf("Field(%s)", field.Name)
 }
 
+// sortedFields returns the Fields sorted by name, so they are validated,
+// introspected, and printed in a consistent order
+func sortedFields(fields map[string]Field) []Field {
+	sorted := make([]Field, 0, len(fields))
+	for _, field := range fields {
+		sorted = append(sorted, field)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// ResolveFunc produces the value of a Field during execution. Fields declared
+// without a ResolveFunc are resolved from their parent value by name
+type ResolveFunc func(params ResolveParams) (interface{}, error)
+
+// ResolveParams describes the values available to a ResolveFunc
+type ResolveParams struct {
+	Context   context.Context        // Context of the Operation being executed
+	Source    interface{}            // Resolved value of the parent Field
+	Arguments map[string]interface{} // Coerced Argument values for the Field
+}
+
+// ResolveTypeFunc returns the name of the Object type a value resolved for an
+// Interface or Union belongs to
+type ResolveTypeFunc func(value interface{}) string
+
 // Type represents a Type in a Schema
 type Type struct {
 	Name    string
@@ -226,15 +417,113 @@ func (t Type) String() string {
 
 // Argument defines an argument for a Field defined within a Type
 type Argument struct {
-	Name    string
-	Type    Type
-	Default interface{}
+	Name              string
+	Description       string
+	Type              Type
+	Default           interface{}
+	DeprecationReason string // Reason the Argument is deprecated for; empty if it is not deprecated
 }
 
 func (argument Argument) String() string {
 	return fmt.Sprintf("Argument(%s)", argument.Name)
 }
 
+// sortedArguments returns the Arguments sorted by name, so they are validated
+// and printed in a consistent order
+func sortedArguments(arguments map[string]Argument) []Argument {
+	sorted := make([]Argument, 0, len(arguments))
+	for _, argument := range arguments {
+		sorted = append(sorted, argument)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// DefaultDeprecationReason is the reason given by the @deprecated directive
+// when it is used without a reason
+const DefaultDeprecationReason = "No longer supported"
+
+// Directive describes a directive that may annotate the given locations of a
+// document or schema definition. A Directive may only be used once at a
+// location unless it is Repeatable
+type Directive struct {
+	Name        string
+	Description string
+	Arguments   map[string]Argument
+	Repeatable  bool
+	Locations   []DirectiveLocation
+}
+
+func (directive Directive) String() string {
+	return fmt.Sprintf("Directive(@%s)", directive.Name)
+}
+
+// DirectiveLocation is a location in a document or schema definition where a
+// Directive may be used
+type DirectiveLocation string
+
+// DirectiveLocations represents the complete set of locations a Directive can
+// be used at
+const (
+	// Locations in executable documents
+	LocationQuery              DirectiveLocation = "QUERY"
+	LocationMutation           DirectiveLocation = "MUTATION"
+	LocationSubscription       DirectiveLocation = "SUBSCRIPTION"
+	LocationField              DirectiveLocation = "FIELD"
+	LocationFragmentDefinition DirectiveLocation = "FRAGMENT_DEFINITION"
+	LocationFragmentSpread     DirectiveLocation = "FRAGMENT_SPREAD"
+	LocationInlineFragment     DirectiveLocation = "INLINE_FRAGMENT"
+	LocationVariableDefinition DirectiveLocation = "VARIABLE_DEFINITION"
+
+	// Locations in schema definitions
+	LocationSchema               DirectiveLocation = "SCHEMA"
+	LocationScalar               DirectiveLocation = "SCALAR"
+	LocationObject               DirectiveLocation = "OBJECT"
+	LocationFieldDefinition      DirectiveLocation = "FIELD_DEFINITION"
+	LocationArgumentDefinition   DirectiveLocation = "ARGUMENT_DEFINITION"
+	LocationInterface            DirectiveLocation = "INTERFACE"
+	LocationUnion                DirectiveLocation = "UNION"
+	LocationEnum                 DirectiveLocation = "ENUM"
+	LocationEnumValue            DirectiveLocation = "ENUM_VALUE"
+	LocationInputObject          DirectiveLocation = "INPUT_OBJECT"
+	LocationInputFieldDefinition DirectiveLocation = "INPUT_FIELD_DEFINITION"
+)
+
+// directiveLocations is the set of every valid DirectiveLocation
+var directiveLocations = map[DirectiveLocation]struct{}{
+	LocationQuery:                struct{}{},
+	LocationMutation:             struct{}{},
+	LocationSubscription:         struct{}{},
+	LocationField:                struct{}{},
+	LocationFragmentDefinition:   struct{}{},
+	LocationFragmentSpread:       struct{}{},
+	LocationInlineFragment:       struct{}{},
+	LocationVariableDefinition:   struct{}{},
+	LocationSchema:               struct{}{},
+	LocationScalar:               struct{}{},
+	LocationObject:               struct{}{},
+	LocationFieldDefinition:      struct{}{},
+	LocationArgumentDefinition:   struct{}{},
+	LocationInterface:            struct{}{},
+	LocationUnion:                struct{}{},
+	LocationEnum:                 struct{}{},
+	LocationEnumValue:            struct{}{},
+	LocationInputObject:          struct{}{},
+	LocationInputFieldDefinition: struct{}{},
+}
+
+// AllowsLocation returns true if the Directive may be used at the location
+func (directive Directive) AllowsLocation(location DirectiveLocation) bool {
+	for _, allowed := range directive.Locations {
+		if allowed == location {
+			return true
+		}
+	}
+	return false
+}
+
 var errTypeNotFound = errors.New("type not found")
 
 func (schema *Schema) getEnum(name string) (Enum, error) {
@@ -345,6 +634,15 @@ func DescribeNonNullListType(subType Type) (t Type) {
 	return
 }
 
+// DescribeASTType returns the TypeSchema for a Type described in a Document
+func DescribeASTType(astType language.Type) Type {
+	if astType.List {
+		subType := DescribeASTType(*astType.SubType)
+		return Type{List: true, NonNull: astType.NonNull, SubType: &subType}
+	}
+	return Type{Name: astType.Type, NonNull: astType.NonNull}
+}
+
 ///
 // Common Pre-defined Types (Int, Float, String, Boolean, ID)
 ///
@@ -384,19 +682,67 @@ var NonNullIDType = DescribeNonNullType("ID")
 ///
 
 // ValidationError represents an error that occured during the declaration
-// or build phase of a Schema. The error includes a message describing the issue
-// encountered
+// or build phase of a Schema. Type, Directive, Field, and Argument name the
+// declarations the problem was found in, and are empty if it was not found in
+// one of them. Message describes the problem itself
 type ValidationError struct {
-	message string
+	Type      string // Name of the type declaration
+	Directive string // Name of the Directive declaration
+	Field     string // Name of the Field of Type
+	Argument  string // Name of the Argument of Field or Directive
+	Message   string
+
+	context string // The declarations the problem was found in, as the error describes them
 }
 
-// NewValidationError returns a new SchemaValidationError
+// NewValidationError returns a new ValidationError with the message
 func NewValidationError(message string) ValidationError {
-	return ValidationError{
-		message: fmt.Sprintf("schema validation error: %s", message),
-	}
+	return ValidationError{Message: message}
 }
 
 func (err ValidationError) Error() string {
-	return err.message
+	if err.context == "" {
+		return "schema validation error: " + err.Message
+	}
+	return fmt.Sprintf("schema validation error: %s %s", err.context, err.Message)
+}
+
+// validationErr returns a ValidationError describing a problem that has not
+// been attributed to a declaration yet
+func validationErr(format string, s ...interface{}) error {
+	return NewValidationError(fmt.Sprintf(format, s...))
+}
+
+// within returns err, a ValidationError, as found within the declaration: a
+// Declaration, Directive, Field, or Argument, or a description of one
+func within(declaration interface{}, err error) error {
+	found := err.(ValidationError)
+	switch d := declaration.(type) {
+	case Declaration:
+		found.Type = d.GetName()
+	case Directive:
+		found.Directive = d.Name
+	case Field:
+		found.Field = d.Name
+	case Argument:
+		found.Argument = d.Name
+	}
+
+	if found.context == "" {
+		found.context = fmt.Sprint(declaration)
+	} else {
+		found.context = fmt.Sprintf("%s %s", declaration, found.context)
+	}
+	return found
+}
+
+// ValidationErrors is every ValidationError found while building a Schema
+type ValidationErrors []ValidationError
+
+func (errs ValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "\n")
 }
@@ -0,0 +1,306 @@
+package schema
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/WilsonGiese/graphql/language"
+)
+
+// ParseSDL parses a document written in the GraphQL schema definition language
+// from an io.Reader and declares its definitions with a new Builder, so they
+// are validated exactly like declarations made in Go. Resolvers, ResolveType
+// functions, and Scalar coercion functions cannot be written in a schema
+// definition; attach them to the Builder with Resolve, ResolveType, and
+// ScalarFuncs before building the Schema. Comments carry no meaning in a
+// schema definition and are ignored. Returns an error if the document cannot
+// be tokenized or parsed. Default values that are invalid for their types are
+// reported by BuildE along with every other problem found
+func ParseSDL(r io.Reader) (*Builder, error) {
+	document, err := language.ParseSchemaReader(r)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := NewSchema().
+		Query(document.RootTypes["query"]).
+		Mutation(document.RootTypes["mutation"]).
+		Subscription(document.RootTypes["subscription"])
+
+	definitions := make(map[string]language.TypeDefinition)
+	for _, definition := range document.Types {
+		definitions[definition.GetName()] = definition
+	}
+	converter := sdlConverter{builder: builder, definitions: definitions}
+
+	for _, definition := range document.Types {
+		builder.Declare(converter.declaration(definition))
+	}
+	for _, definition := range document.Directives {
+		builder.Directive(converter.directive(definition))
+	}
+	return builder, nil
+}
+
+// sdlConverter converts the definitions of a SchemaDocument to Declarations,
+// recording a ValidationError with the Builder for every default value that is
+// not a valid value of its type
+type sdlConverter struct {
+	builder     *Builder
+	definitions map[string]language.TypeDefinition // Every type definition of the document by name
+}
+
+func (c sdlConverter) declaration(definition language.TypeDefinition) Declaration {
+	switch d := definition.(type) {
+	case language.ScalarDefinition:
+		return Scalar{Name: d.Name, Description: d.Description, SpecifiedByURL: specifiedByURL(d.Directives)}
+	case language.ObjectDefinition:
+		object := Object{Name: d.Name, Description: d.Description, Implements: d.Implements}
+		object.Fields = c.fields(object, d.Fields)
+		return object
+	case language.InterfaceDefinition:
+		intrface := Interface{Name: d.Name, Description: d.Description}
+		intrface.Fields = c.fields(intrface, d.Fields)
+		return intrface
+	case language.UnionDefinition:
+		return Union{Name: d.Name, Description: d.Description, Types: d.Types}
+	case language.EnumDefinition:
+		enum := Enum{Name: d.Name, Description: d.Description}
+		for _, value := range d.Values {
+			enum.Values = append(enum.Values, EnumValue{
+				Name:              value.Name,
+				Description:       value.Description,
+				DeprecationReason: deprecationReason(value.Directives),
+			})
+		}
+		return enum
+	case language.InputDefinition:
+		input := Input{Name: d.Name, Description: d.Description, Fields: make(map[string]Field)}
+		for _, definition := range d.Fields {
+			field := Field{
+				Name:              definition.Name,
+				Description:       definition.Description,
+				Type:              DescribeASTType(definition.Type),
+				DeprecationReason: deprecationReason(definition.Directives),
+			}
+			field.Default = c.defaultValueOf(definition.Default, field.Type, input, field)
+			input.Fields[field.Name] = field
+		}
+		return input
+	}
+	// NOTE: unreachable only if type switch covers all TypeDefinition types
+	panic("unreachable")
+}
+
+func (c sdlConverter) directive(definition language.DirectiveDefinition) Directive {
+	directive := Directive{
+		Name:        definition.Name,
+		Description: definition.Description,
+		Repeatable:  definition.Repeatable,
+	}
+	directive.Arguments = c.arguments(definition.Arguments, directive)
+	for _, location := range definition.Locations {
+		directive.Locations = append(directive.Locations, DirectiveLocation(location))
+	}
+	return directive
+}
+
+// fields converts the FieldDefinitions of the Object or Interface
+func (c sdlConverter) fields(owner Declaration, definitions []language.FieldDefinition) map[string]Field {
+	fields := make(map[string]Field)
+	for _, definition := range definitions {
+		field := Field{
+			Name:              definition.Name,
+			Description:       definition.Description,
+			Type:              DescribeASTType(definition.Type),
+			DeprecationReason: deprecationReason(definition.Directives),
+		}
+		field.Arguments = c.arguments(definition.Arguments, owner, field)
+		fields[field.Name] = field
+	}
+	return fields
+}
+
+// arguments converts the argument definitions of the Field or Directive
+// declared within owners, or returns nil if there are none
+func (c sdlConverter) arguments(definitions []language.InputValueDefinition, owners ...interface{}) map[string]Argument {
+	if len(definitions) == 0 {
+		return nil
+	}
+
+	arguments := make(map[string]Argument)
+	for _, definition := range definitions {
+		arguments[definition.Name] = c.argument(definition, owners...)
+	}
+	return arguments
+}
+
+// argument converts an InputValueDefinition of the Field or Directive
+// declared within owners
+func (c sdlConverter) argument(definition language.InputValueDefinition, owners ...interface{}) Argument {
+	argument := Argument{
+		Name:              definition.Name,
+		Description:       definition.Description,
+		Type:              DescribeASTType(definition.Type),
+		DeprecationReason: deprecationReason(definition.Directives),
+	}
+	argument.Default = c.defaultValueOf(definition.Default, argument.Type, append(owners, argument)...)
+	return argument
+}
+
+// defaultValueOf converts the default value, if any, of an Argument or Input
+// field. Records a ValidationError found within the declarations, from the
+// outermost to the Argument or field, if the value is not a valid value of the
+// type t
+func (c sdlConverter) defaultValueOf(value language.Value, t Type, declarations ...interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+
+	resolved, err := c.defaultValue(value, t)
+	if err != nil {
+		err = validationErr("declared with an invalid default value: %s", err)
+		for i := len(declarations) - 1; i >= 0; i-- {
+			err = within(declarations[i], err)
+		}
+		c.builder.report(err)
+	}
+	return resolved
+}
+
+// deprecationReason returns the reason given by a @deprecated directive, or
+// an empty string if the directives do not include @deprecated
+func deprecationReason(directives []language.Directive) string {
+	for _, directive := range directives {
+		if directive.Name != "deprecated" {
+			continue
+		}
+
+		if reason, isString := directive.Arguments["reason"].Value.(language.StringValue); isString {
+			return reason.Value
+		}
+		return DefaultDeprecationReason
+	}
+	return ""
+}
+
+// specifiedByURL returns the URL given by a @specifiedBy directive, or an empty
+// string if the directives do not include @specifiedBy
+func specifiedByURL(directives []language.Directive) string {
+	for _, directive := range directives {
+		if directive.Name != "specifiedBy" {
+			continue
+		}
+
+		if url, isString := directive.Arguments["url"].Value.(language.StringValue); isString {
+			return url.Value
+		}
+	}
+	return ""
+}
+
+// defaultValue converts a default value to a Go value of the type t. Returns an
+// error if the value is not a valid value of t. Values of types the document
+// does not define are converted as they are, since the undefined types are
+// reported when the Schema is built
+func (c sdlConverter) defaultValue(value language.Value, t Type) (interface{}, error) {
+	switch value.(type) {
+	case language.NullValue:
+		if t.NonNull {
+			return nil, fmt.Errorf("expected non-null value of type '%s' but found null", t)
+		}
+		return nil, nil
+	case language.Variable:
+		return nil, fmt.Errorf("default values cannot use variable '%s'", value)
+	}
+
+	if t.List {
+		list, isList := value.(language.ListValue)
+
+		// A single value is coerced to a list containing only that value
+		if !isList {
+			item, err := c.defaultValue(value, *t.SubType)
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{item}, nil
+		}
+
+		items := make([]interface{}, len(list.Values))
+		for i, item := range list.Values {
+			resolved, err := c.defaultValue(item, *t.SubType)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = resolved
+		}
+		return items, nil
+	}
+
+	switch definition := c.definitions[t.Name].(type) {
+	case language.EnumDefinition:
+		if literal, isEnum := value.(language.EnumValue); isEnum {
+			for _, enumValue := range definition.Values {
+				if enumValue.Name == literal.Value {
+					return enumValue.Name, nil
+				}
+			}
+		}
+	case language.InputDefinition:
+		object, isObject := value.(language.ObjectValue)
+		if !isObject {
+			break
+		}
+
+		fields := make(map[string]language.InputValueDefinition)
+		for _, field := range definition.Fields {
+			fields[field.Name] = field
+		}
+
+		resolved := make(map[string]interface{})
+		for _, name := range sortedObjectFieldNames(object.Fields) {
+			field, exists := fields[name]
+			if !exists {
+				return nil, fmt.Errorf("field '%s' is not defined by type '%s'", name, definition.Name)
+			}
+
+			fieldValue, err := c.defaultValue(object.Fields[name].Value, DescribeASTType(field.Type))
+			if err != nil {
+				return nil, err
+			}
+			resolved[name] = fieldValue
+		}
+
+		for _, field := range definition.Fields {
+			if _, provided := object.Fields[field.Name]; !provided && field.Type.NonNull {
+				return nil, fmt.Errorf("field '%s' of required type '%s' was not provided", field.Name, DescribeASTType(field.Type))
+			}
+		}
+		return resolved, nil
+	case language.ScalarDefinition:
+		// Scalars defined by the document have no ParseLiteral function yet
+		return ParseScalarLiteral(Scalar{Name: definition.Name}, value, nil)
+	case nil:
+		scalar, isBuiltIn := builtInScalars[t.Name]
+		if !isBuiltIn {
+			return ParseScalarLiteral(Scalar{Name: t.Name}, value, nil)
+		}
+
+		if parsed, err := ParseScalarLiteral(scalar, value, nil); err == nil {
+			return parsed, nil
+		}
+	}
+	return nil, fmt.Errorf("expected value of type '%s' but found %s", t, value)
+}
+
+// sortedObjectFieldNames returns the names of the fields of an ObjectValue in
+// sorted order
+func sortedObjectFieldNames(fields map[string]language.ObjectField) []string {
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
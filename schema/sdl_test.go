@@ -0,0 +1,339 @@
+package schema
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const sampleSDL = `
+# Sample schema used by the SDL tests
+schema {
+  query: QueryRoot
+}
+
+"Commands that a Dog may know"
+enum DogCommand { "Sit down" SIT, DOWN, HEEL @deprecated(reason: "Dogs no longer heel") }
+
+scalar Time @specifiedBy(url: "https://tools.ietf.org/html/rfc3339")
+
+interface Pet {
+  name: String!
+}
+
+"Pet type Dog"
+type Dog implements Pet {
+  "Name of this Dog"
+  name: String!
+  nickname: String @deprecated(reason: "Use name")
+  doesKnowCommand("The command to check" dogCommand: DogCommand = SIT): Boolean!
+  barkVolume(scale: Float = 1.5, times: [Int] = 2): Int @deprecated
+  born: Time
+}
+
+"""
+  Pet type Cat
+
+  Cats do not know any \"""commands\"""
+"""
+type Cat implements & Pet {
+  name: String!
+}
+
+union CatOrDog = | Cat | Dog
+
+input DogFilter {
+  name: String @deprecated
+  limit: Int = 10
+  commands: [DogCommand!]
+}
+
+directive @cached(seconds: Int) repeatable on FIELD_DEFINITION | OBJECT
+
+type QueryRoot {
+  dog(filter: DogFilter = {name: "Rex"}): Dog
+  pets: [CatOrDog]
+}
+`
+
+// buildSDL parses a schema definition document and builds its Schema
+func buildSDL(sdl string) (*Schema, error) {
+	builder, err := ParseSDL(strings.NewReader(sdl))
+	if err != nil {
+		return nil, err
+	}
+	return builder.BuildE()
+}
+
+func TestParseSDL(t *testing.T) {
+	s, err := buildSDL(sampleSDL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	enum, isEnum := s.GetDeclaration(DescribeType("DogCommand")).(Enum)
+	if !isEnum {
+		t.Fatal("expected DogCommand to be declared as an Enum")
+	}
+	expectedValues := []EnumValue{
+		{Name: "SIT", Description: "Sit down"},
+		{Name: "DOWN"},
+		{Name: "HEEL", DeprecationReason: "Dogs no longer heel"},
+	}
+	if enum.Description != "Commands that a Dog may know" || !reflect.DeepEqual(enum.Values, expectedValues) {
+		t.Errorf("unexpected Enum: %+v", enum)
+	}
+
+	dog, isObject := s.GetDeclaration(DescribeType("Dog")).(Object)
+	if !isObject {
+		t.Fatal("expected Dog to be declared as an Object")
+	}
+	if dog.Description != "Pet type Dog" || !reflect.DeepEqual(dog.Implements, []string{"Pet"}) {
+		t.Errorf("unexpected Object: %+v", dog)
+	}
+
+	doesKnowCommand := dog.Fields["doesKnowCommand"]
+	expectedArgument := Argument{
+		Name:        "dogCommand",
+		Description: "The command to check",
+		Type:        DescribeType("DogCommand"),
+		Default:     "SIT",
+	}
+	if doesKnowCommand.Type != NonNullBooleanType || !reflect.DeepEqual(doesKnowCommand.Arguments["dogCommand"], expectedArgument) {
+		t.Errorf("unexpected Field: %+v", doesKnowCommand)
+	}
+
+	if reason := dog.Fields["nickname"].DeprecationReason; reason != "Use name" {
+		t.Errorf("unexpected deprecation reason: %q", reason)
+	}
+
+	barkVolume := dog.Fields["barkVolume"]
+	if barkVolume.DeprecationReason != DefaultDeprecationReason {
+		t.Errorf("unexpected deprecation reason: %q", barkVolume.DeprecationReason)
+	}
+	if barkVolume.Arguments["scale"].Default != 1.5 || !reflect.DeepEqual(barkVolume.Arguments["times"].Default, []interface{}{2}) {
+		t.Errorf("unexpected default values: %+v", barkVolume.Arguments)
+	}
+
+	cat, _ := s.GetDeclaration(DescribeType("Cat")).(Object)
+	if cat.Description != "Pet type Cat\n\nCats do not know any \"\"\"commands\"\"\"" || !reflect.DeepEqual(cat.Implements, []string{"Pet"}) {
+		t.Errorf("unexpected Object: %+v", cat)
+	}
+
+	union, _ := s.GetDeclaration(DescribeType("CatOrDog")).(Union)
+	if !reflect.DeepEqual(union.Types, []string{"Cat", "Dog"}) {
+		t.Errorf("unexpected Union: %+v", union)
+	}
+
+	input, _ := s.GetDeclaration(DescribeType("DogFilter")).(Input)
+	if input.Fields["commands"].Type.String() != "[DogCommand!]" || input.Fields["name"].DeprecationReason != DefaultDeprecationReason || input.Fields["limit"].Default != 10 {
+		t.Errorf("unexpected Input: %+v", input)
+	}
+
+	queryRoot, _ := s.GetDeclaration(DescribeType("QueryRoot")).(Object)
+	if !reflect.DeepEqual(queryRoot.Fields["dog"].Arguments["filter"].Default, map[string]interface{}{"name": "Rex"}) {
+		t.Errorf("unexpected default value: %+v", queryRoot.Fields["dog"].Arguments["filter"])
+	}
+
+	cached, _ := s.GetDirective("cached")
+	expectedDirective := Directive{
+		Name:       "cached",
+		Arguments:  Arguments(Argument{Name: "seconds", Type: IntType}),
+		Repeatable: true,
+		Locations:  Locations(LocationFieldDefinition, LocationObject),
+	}
+	if !reflect.DeepEqual(cached, expectedDirective) {
+		t.Errorf("unexpected Directive: %+v", cached)
+	}
+
+	if time, isScalar := s.GetDeclaration(DescribeType("Time")).(Scalar); !isScalar {
+		t.Error("expected Time to be declared as a Scalar")
+	} else if time.SpecifiedByURL != "https://tools.ietf.org/html/rfc3339" {
+		t.Errorf("unexpected Scalar specifiedByURL: %s", time.SpecifiedByURL)
+	}
+
+	if queryType, _ := s.QueryType(); queryType.Name != "QueryRoot" {
+		t.Errorf("unexpected query root type: %s", queryType.Name)
+	}
+}
+
+func TestParseSDLRootTypes(t *testing.T) {
+	s, err := buildSDL(`
+schema { query: Root mutation: Change }
+type Root { name: String }
+type Change { rename(name: String): String }
+type Query { unused: String }
+type Subscription { renamed: String }
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if queryType, _ := s.QueryType(); queryType.Name != "Root" {
+		t.Errorf("unexpected query root type: %s", queryType.Name)
+	}
+	if mutationType, _ := s.MutationType(); mutationType.Name != "Change" {
+		t.Errorf("unexpected mutation root type: %s", mutationType.Name)
+	}
+	if _, supported := s.SubscriptionType(); supported {
+		t.Error("expected subscriptions to be unsupported")
+	}
+}
+
+func TestParseSDLNestedLists(t *testing.T) {
+	s, err := buildSDL(`type Query { a: [[Int]] b: [[Int!]!] }`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queryType, _ := s.QueryType()
+	for name, expected := range map[string]Type{
+		"a": DescribeListType(DescribeListType(IntType)),
+		"b": DescribeListType(DescribeNonNullListType(NonNullIntType)),
+	} {
+		if actual := queryType.Fields[name].Type; !reflect.DeepEqual(actual, expected) {
+			t.Errorf("Field %s: expected type %s but found %s", name, expected, actual)
+		}
+	}
+}
+
+var invalidSDLTests = []struct {
+	sdl      string
+	expected string
+}{
+	{`type Dog { name: String`, "Expected Name but found EOF"},
+	{`query { dog }`, "Expected schema or scalar or type or interface or union or enum or input or directive but found query"},
+	{`schema { query: Pet } interface Pet { name: String }`, "schema validation error: Query root type 'Pet' must be a declared Object"},
+	{`schema { query: Query query: Query } type Query { name: String }`, "invalid: duplicate query root type in schema definition"},
+	{`type Dog { name: String } directive @cached on NOWHERE`, "schema validation error: Directive(@cached) declared with unknown location 'NOWHERE'"},
+	{`type Dog { name: Strin }`, "schema validation error: Object(Dog) Field(name) declared with unknown type 'Strin'"},
+	{`type Query { a(x: Int = "abc"): Int }`, "schema validation error: Object(Query) Field(a) Argument(x) declared with an invalid default value: expected value of type 'Int' but found \"abc\""},
+	{`type Query { a(x: [Color] = [RED, BLUE]): Int } enum Color { RED }`, "schema validation error: Object(Query) Field(a) Argument(x) declared with an invalid default value: expected value of type 'Color' but found BLUE"},
+	{`type Query { a(x: Page = {limit: 1}): Int } input Page { limit: Int = $limit offset: Int! }`, "schema validation error: Object(Query) Field(a) Argument(x) declared with an invalid default value: field 'offset' of required type 'Int!' was not provided\nschema validation error: Input(Page) Field(limit) declared with an invalid default value: default values cannot use variable '$limit'"},
+	{`type Query { a: Int } directive @cached(seconds: Int = 1.5) on FIELD`, "schema validation error: Directive(@cached) Argument(seconds) declared with an invalid default value: expected value of type 'Int' but found 1.5"},
+	{`type Query { a(x: Strin = 1): Int }`, "schema validation error: Object(Query) Field(a) Argument(x) declared with unknown type 'Strin'"},
+	{`type Dog implements Pet { name: String }`, "schema validation error: Object(Dog) declared implementing unknown Interface 'Pet'"},
+	{`type Dog implements Pet { name: Strin }`, "schema validation error: Object(Dog) Field(name) declared with unknown type 'Strin'\nschema validation error: Object(Dog) declared implementing unknown Interface 'Pet'"},
+}
+
+func TestParseSDLInvalid(t *testing.T) {
+	for _, test := range invalidSDLTests {
+		_, err := buildSDL(test.sdl)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("ParseSDL(%q)\n  expected: %s\n    actual: %v", test.sdl, test.expected, err)
+		}
+	}
+}
+
+// Printing a parsed Schema and parsing it again must describe the same Schema
+func TestParseSDLRoundTrip(t *testing.T) {
+	s, err := buildSDL(sampleSDL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	printed := PrintSDL(s)
+
+	reparsed, err := buildSDL(printed)
+	if err != nil {
+		t.Fatalf("ParseSDL failed on printed Schema: %s\n%s", err, printed)
+	}
+
+	if reprinted := PrintSDL(reparsed); reprinted != printed {
+		t.Errorf("round trip changed the Schema\n  expected: %s\n    actual: %s", printed, reprinted)
+	}
+}
+
+// Objects named like root types that are not root types must remain so when
+// the printed Schema is parsed again
+var rootTypesRoundTripTests = []string{
+	`schema { query: Query } type Query { a: Int } type Mutation { b: Int } type Subscription { c: Int }`,
+	`schema { query: Query mutation: MutationRoot } type Query { a: Int } type Mutation { b: Int } type MutationRoot { c: Int }`,
+	`schema { query: QueryRoot } type QueryRoot { a: Int }`,
+}
+
+func TestParseSDLRoundTripRootTypes(t *testing.T) {
+	for _, sdl := range rootTypesRoundTripTests {
+		s, err := buildSDL(sdl)
+		if err != nil {
+			t.Fatal(err)
+		}
+		printed := PrintSDL(s)
+
+		reparsed, err := buildSDL(printed)
+		if err != nil {
+			t.Fatalf("ParseSDL failed on printed Schema: %s\n%s", err, printed)
+		}
+
+		for operationType, rootTypes := range map[string][2]string{
+			"query":        {s.queryType, reparsed.queryType},
+			"mutation":     {s.mutationType, reparsed.mutationType},
+			"subscription": {s.subscriptionType, reparsed.subscriptionType},
+		} {
+			if rootTypes[0] != rootTypes[1] {
+				t.Errorf("%s: expected %s root type '%s' but found '%s'\n%s", sdl, operationType, rootTypes[0], rootTypes[1], printed)
+			}
+		}
+	}
+}
+
+func TestParseSDLResolvers(t *testing.T) {
+	builder, err := ParseSDL(strings.NewReader(`
+scalar Time
+interface Pet { name: String }
+union Named = Dog
+type Dog implements Pet { name: String }
+type Query { pet: Pet }
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolvePet := func(ResolveParams) (interface{}, error) { return "Rex", nil }
+	resolveDog := func(interface{}) string { return "Dog" }
+	serializeTime := func(value interface{}) (interface{}, error) { return value, nil }
+	s, err := builder.
+		Resolve("Query", "pet", resolvePet).
+		ResolveType("Pet", resolveDog).
+		ResolveType("Named", resolveDog).
+		ScalarFuncs("Time", serializeTime, serializeTime, serializeTime).
+		BuildE()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queryType, _ := s.QueryType()
+	if queryType.Fields["pet"].Resolve == nil {
+		t.Error("expected Query pet Field to have a ResolveFunc")
+	}
+	if pet, _ := s.GetDeclaration(DescribeType("Pet")).(Interface); pet.ResolveType == nil {
+		t.Error("expected Pet Interface to have a ResolveTypeFunc")
+	}
+	if named, _ := s.GetDeclaration(DescribeType("Named")).(Union); named.ResolveType == nil {
+		t.Error("expected Named Union to have a ResolveTypeFunc")
+	}
+	if time, _ := s.GetDeclaration(DescribeType("Time")).(Scalar); time.Serialize == nil || time.ParseValue == nil || time.ParseLiteral == nil {
+		t.Error("expected Time Scalar to have coercion functions")
+	}
+}
+
+func TestParseSDLResolversUndeclared(t *testing.T) {
+	builder, err := ParseSDL(strings.NewReader(`type Query { name: String }`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = builder.
+		Resolve("Dog", "name", nil).
+		Resolve("Query", "age", nil).
+		ResolveType("Query", nil).
+		ScalarFuncs("Time", nil, nil, nil).
+		BuildE()
+
+	expected := "schema validation error: ResolveFunc set for Field(name) of undeclared Object 'Dog'\n" +
+		"schema validation error: Object(Query) ResolveFunc set for undeclared Field 'age'\n" +
+		"schema validation error: ResolveTypeFunc set for undeclared Interface or Union 'Query'\n" +
+		"schema validation error: Scalar functions set for undeclared Scalar 'Time'"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected: %s\n  actual: %v", expected, err)
+	}
+}
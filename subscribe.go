@@ -0,0 +1,94 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	schema "github.com/WilsonGiese/graphql/schema"
+)
+
+// Subscribe starts a subscription Operation from the document against the
+// schema. The ResolveFunc of the subscription's root field must return a
+// receivable channel; every value received from it is an event, and produces a
+// Result by executing the Operation's selection set with the event as the value
+// of the root field. The returned channel is closed once the event channel is
+// closed or ctx is done, and ctx is given to the root field's ResolveFunc so it
+// can stop producing events. Returns an error if the subscription cannot be
+// started. The document is assumed to have been validated against the schema
+func Subscribe(ctx context.Context, s *schema.Schema, document *Document, operationName string, variables map[string]interface{}, rootValue interface{}) (<-chan *Result, error) {
+	e := executor{
+		ctx:      ctx,
+		schema:   s,
+		document: document,
+	}
+
+	operation, rootType, err := e.prepare(operationName, variables)
+	if err != nil {
+		return nil, err
+	}
+	if operation.Type != "subscription" {
+		return nil, fmt.Errorf("Subscribe requires a subscription operation but found a %s operation", operation.Type)
+	}
+
+	fields := make(map[string][]Field)
+	var responseKeys []string
+	e.collectFields(rootType, operation.SelectionSet, make(map[string]struct{}), fields, &responseKeys)
+	if len(responseKeys) != 1 {
+		return nil, errors.New("A subscription operation must select exactly one root field")
+	}
+	responseKey := responseKeys[0]
+
+	fieldDefinition, exists := rootType.Fields[fields[responseKey][0].Name]
+	if !exists {
+		return nil, fmt.Errorf("Type '%s' does not contain the field '%s'", rootType.Name, fields[responseKey][0].Name)
+	}
+
+	stream, err := e.resolveField(fieldDefinition, fields[responseKey][0], rootValue)
+	if err != nil {
+		return nil, err
+	}
+
+	events := reflect.ValueOf(stream)
+	if events.Kind() != reflect.Chan || events.Type().ChanDir()&reflect.RecvDir == 0 {
+		return nil, fmt.Errorf("Subscription field '%s' must resolve to a receivable channel but resolved to %T", fieldDefinition.Name, stream)
+	}
+
+	results := make(chan *Result)
+	go func() {
+		defer close(results)
+
+		cases := []reflect.SelectCase{
+			{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ctx.Done())},
+			{Dir: reflect.SelectRecv, Chan: events},
+		}
+		for {
+			chosen, event, received := reflect.Select(cases)
+			if chosen == 0 || !received {
+				return
+			}
+
+			result := e.executeSubscriptionEvent(fieldDefinition, fields[responseKey], responseKey, event.Interface())
+			select {
+			case results <- result:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return results, nil
+}
+
+// executeSubscriptionEvent produces the Result for a single subscription event
+// by completing the event as the value of the subscription's root field
+func (e executor) executeSubscriptionEvent(fieldDefinition schema.Field, fields []Field, responseKey string, event interface{}) *Result {
+	// Every event is executed independently, so errors must not be shared
+	e.errors = nil
+
+	value, ok := e.completeValue(fieldDefinition.Type, fields, event, []interface{}{responseKey})
+	if !ok {
+		return &Result{Errors: e.errors}
+	}
+	return &Result{Data: ResultMap{{Key: responseKey, Value: value}}, Errors: e.errors}
+}
@@ -0,0 +1,97 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func parseTestDocument(t *testing.T, query string) Document {
+	tokens, err := Tokenize(strings.NewReader(query), true)
+	if err != nil {
+		t.Fatalf("Tokenize(%q) failed: %s", query, err)
+	}
+
+	document, err := Parse(tokens)
+	if err != nil {
+		t.Fatalf("Parse(%q) failed: %s", query, err)
+	}
+	return document
+}
+
+func TestSubscribe(t *testing.T) {
+	document := parseTestDocument(t, `subscription Barks { barked: dogBarked { name ... on Dog { barkVolume } } }`)
+
+	events := make(chan testDog)
+	results, err := Subscribe(context.Background(), executionSchema, &document, "", nil, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go func() {
+		events <- testDog{Name: "Rex", BarkVolume: 10}
+		events <- testDog{Name: "Fido", BarkVolume: 3}
+		close(events)
+	}()
+
+	expected := []string{
+		`{"data":{"barked":{"name":"Rex","barkVolume":10}}}`,
+		`{"data":{"barked":{"name":"Fido","barkVolume":3}}}`,
+	}
+	for _, expectedResult := range expected {
+		result, _ := json.Marshal(<-results)
+		if string(result) != expectedResult {
+			t.Errorf("expected: %s\n  actual: %s", expectedResult, result)
+		}
+	}
+
+	if result, open := <-results; open {
+		t.Errorf("expected results to be closed but received %+v", result)
+	}
+}
+
+func TestSubscribeCancel(t *testing.T) {
+	document := parseTestDocument(t, `subscription { dogBarked { name } }`)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results, err := Subscribe(ctx, executionSchema, &document, "", nil, make(chan testDog))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	if result, open := <-results; open {
+		t.Errorf("expected results to be closed but received %+v", result)
+	}
+}
+
+var invalidSubscribeTests = []struct {
+	query     string
+	rootValue interface{}
+	expected  string
+}{
+	{`query { dog { name } }`, nil, "Subscribe requires a subscription operation but found a query operation"},
+	{`subscription { dogBarked { name } }`, "not a channel", "Subscription field 'dogBarked' must resolve to a receivable channel but resolved to string"},
+	{`subscription { dogBarked { name } other: dogBarked { name } }`, nil, "A subscription operation must select exactly one root field"},
+}
+
+func TestSubscribeInvalid(t *testing.T) {
+	for _, test := range invalidSubscribeTests {
+		document := parseTestDocument(t, test.query)
+
+		_, err := Subscribe(context.Background(), executionSchema, &document, "", nil, test.rootValue)
+		if err == nil || err.Error() != test.expected {
+			t.Errorf("Subscribe(%q)\n  expected: %s\n    actual: %v", test.query, test.expected, err)
+		}
+	}
+}
+
+func TestExecuteSubscription(t *testing.T) {
+	document := parseTestDocument(t, `subscription { dogBarked { name } }`)
+
+	result, _ := json.Marshal(Execute(executionSchema, &document, "", nil, nil))
+	if expected := `{"data":null,"errors":[{"message":"Subscription operations must be executed with Subscribe"}]}`; string(result) != expected {
+		t.Errorf("expected: %s\n  actual: %s", expected, result)
+	}
+}
@@ -2,216 +2,1610 @@ package graphql
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	schema "github.com/WilsonGiese/graphql/schema"
 )
 
-type validator struct {
-	schema         *schema.Schema
-	document       *Document
-	errors         []error
-	operationNames map[string]struct{} // Set of known Operation names
-	fragmentNames  map[string]struct{} // Set of known Fragment names
+// ValidationError is an error found while validating a Document against a
+// Schema. Locations are the locations of the nodes in the Document that caused
+// the error
+type ValidationError struct {
+	Message   string
+	Locations []Loc
 }
 
-var EXISTS struct{}
+func (err *ValidationError) Error() string {
+	return err.Message
+}
 
-func validate(schema *schema.Schema, document *Document) []error {
-	v := validator{
-		schema:         schema,
-		document:       document,
-		operationNames: make(map[string]struct{}),
-		fragmentNames:  make(map[string]struct{}),
+// Rule is a validation rule for a Document. A Rule inspects the Document given
+// by the ValidationContext and reports every error it finds to it
+type Rule func(context *ValidationContext)
+
+// SpecRules are the validation rules described by the GraphQL specification.
+// Validate uses SpecRules when it is given no rules
+var SpecRules = []Rule{
+	OperationNameUniqueness,
+	LoneAnonymousOperation,
+	OperationTypeExistence,
+	SubscriptionSingleRootField,
+	FieldSelections,
+	OverlappingFieldsCanBeMerged,
+	ArgumentNames,
+	ArgumentValues,
+	RequiredArguments,
+	FragmentNameUniqueness,
+	FragmentSpreadTypeExistence,
+	FragmentSpreadTargetDefined,
+	FragmentsMustBeUsed,
+	NoFragmentCycles,
+	FragmentSpreadIsPossible,
+	DirectivesDefined,
+	DirectivesInValidLocations,
+	DirectivesUniquePerLocation,
+	VariableUniqueness,
+	VariablesAreInputTypes,
+	VariableDefaultValuesAllowed,
+	AllVariableUsesDefined,
+	AllVariablesUsed,
+	VariablesInAllowedPosition,
+}
+
+// Validate validates a Document against a Schema with the given rules, or with
+// SpecRules if no rules are given. Returns every error reported by the rules
+// in the order of the rules
+func Validate(schema *schema.Schema, document *Document, rules ...Rule) []*ValidationError {
+	if len(rules) == 0 {
+		rules = SpecRules
 	}
 
-	for _, operation := range document.Operations {
-		v.validateOperation(operation)
+	context := ValidationContext{Schema: schema, Document: document}
+	for _, rule := range rules {
+		rule(&context)
 	}
+	return context.errors
+}
+
+// ValidationContext is given to every Rule to inspect the Document and report
+// errors
+type ValidationContext struct {
+	Schema   *schema.Schema
+	Document *Document
+	errors   []*ValidationError
+}
+
+// Report reports a validation error caused by the nodes at the given locations
+func (context *ValidationContext) Report(locations []Loc, format string, s ...interface{}) {
+	context.errors = append(context.errors, &ValidationError{
+		Message:   fmt.Sprintf(format, s...),
+		Locations: locations,
+	})
+}
+
+// Visitor contains the functions called by ValidationContext.Walk for the
+// selections of a Document. Any of the functions may be nil
+type Visitor struct {
+	// SelectionSet is called for every selection set with the type it selects
+	// from. The selection sets of fields with undefined types are not visited
+	SelectionSet func(parentType schema.Declaration, selectionSet SelectionSet)
+
+	// Field is called for every field with the type it is selected from, and
+	// its definition, which is nil if the type does not define the field
+	Field func(parentType schema.Declaration, field Field, definition *schema.Field)
+
+	// InlineFragment is called for every inline fragment with the type it is
+	// spread into
+	InlineFragment func(parentType schema.Declaration, inlineFragment InlineFragment)
+
+	// FragmentSpread is called for every fragment spread with the type it is
+	// spread into
+	FragmentSpread func(parentType schema.Declaration, fragmentSpread FragmentSpread)
+}
 
-	for _, fragment := range document.Fragments {
-		v.validateFragment(fragment)
+// Walk walks the selection sets of every Operation whose type the Schema
+// supports, and of every Fragment whose type condition exists in the Schema,
+// calling the functions of the Visitor for each selection. Fragment spreads are
+// not followed since every Fragment is walked on its own
+func (context *ValidationContext) Walk(visitor Visitor) {
+	for _, operation := range context.Document.Operations {
+		if rootType, supported := operationRootType(operation, context.Schema); supported {
+			context.walkSelectionSet(visitor, rootType, operation.SelectionSet)
+		}
 	}
 
-	// Final validation checks
-	//
-	//  Fragments Must Be Used:
-	//    Every fragment must be used at least once
-	//
-	return v.errors
+	for _, fragment := range context.Document.Fragments {
+		if declaration := context.Schema.GetDeclaration(schema.DescribeType(fragment.Type)); declaration != nil {
+			context.walkSelectionSet(visitor, declaration, fragment.SelectionSet)
+		}
+	}
 }
 
+func (context *ValidationContext) walkSelectionSet(visitor Visitor, parentType schema.Declaration, selectionSet SelectionSet) {
+	if visitor.SelectionSet != nil {
+		visitor.SelectionSet(parentType, selectionSet)
+	}
+
+	// Leaf types have no selections to walk
+	switch parentType.(type) {
+	case schema.Object, schema.Interface, schema.Union:
+	default:
+		return
+	}
+
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case Field:
+			definition := context.fieldDefinition(parentType, selection.Name)
+			if visitor.Field != nil {
+				visitor.Field(parentType, selection, definition)
+			}
+
+			if definition != nil {
+				if declaration := context.Schema.GetDeclaration(definition.Type); declaration != nil {
+					context.walkSelectionSet(visitor, declaration, selection.SelectionSet)
+				}
+			}
+		case InlineFragment:
+			if visitor.InlineFragment != nil {
+				visitor.InlineFragment(parentType, selection)
+			}
+
+			// An inline fragment without a type condition selects from its parent type
+			fragmentType := parentType
+			if selection.Type != "" {
+				fragmentType = context.Schema.GetDeclaration(schema.DescribeType(selection.Type))
+			}
+			if fragmentType != nil {
+				context.walkSelectionSet(visitor, fragmentType, selection.SelectionSet)
+			}
+		case FragmentSpread:
+			if visitor.FragmentSpread != nil {
+				visitor.FragmentSpread(parentType, selection)
+			}
+		}
+	}
+}
+
+// fieldDefinition returns the definition of the named field on the type,
+// including introspection meta-fields, or nil if the type does not define the
+// field
+func (context *ValidationContext) fieldDefinition(parentType schema.Declaration, name string) *schema.Field {
+	var fields map[string]schema.Field
+	switch d := parentType.(type) {
+	case schema.Object:
+		fields = d.Fields
+	case schema.Interface:
+		fields = d.Fields
+	}
+
+	if field, exists := fields[name]; exists {
+		return &field
+	}
+	if field, exists := context.Schema.MetaField(parentType, name); exists {
+		return &field
+	}
+	return nil
+}
+
+// collectRootFields appends every field in the selection set to fields in
+// order, including fields selected through fragments
+func (context *ValidationContext) collectRootFields(selectionSet SelectionSet, fields *[]Field, visitedFragments map[string]struct{}) {
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case Field:
+			*fields = append(*fields, selection)
+		case InlineFragment:
+			context.collectRootFields(selection.SelectionSet, fields, visitedFragments)
+		case FragmentSpread:
+			if _, visited := visitedFragments[selection.Name]; visited {
+				continue
+			}
+			visitedFragments[selection.Name] = EXISTS
+
+			if fragment, err := context.Document.GetFragment(selection.Name); err == nil {
+				context.collectRootFields(fragment.SelectionSet, fields, visitedFragments)
+			}
+		}
+	}
+}
+
+var EXISTS struct{}
+
 // Operation Rules
-//  Operation Name Uniqueness:
-//    Every operation must have a unique name, even if they have differing
-//    operation types (e.g. query & mutation)
-//
-//  Lone Anonymous Operation:
-//    If any anonymous operation (namless) exists, no other operation can be
-//    defined
-func (v *validator) validateOperation(operation Operation) {
-	// Lone Anonymous Operation
-	if operation.Name == "" {
-		if len(v.document.Operations) > 1 {
-			v.error("Lone Anonymous Operation error: more than one operation defined with anonymous operation")
+
+// OperationNameUniqueness requires every operation to have a unique name, even
+// if they have differing operation types (e.g. query & mutation)
+func OperationNameUniqueness(context *ValidationContext) {
+	operationNames := make(map[string]struct{})
+	for _, operation := range context.Document.Operations {
+		if operation.Name == "" {
+			continue
+		}
+
+		if _, exists := operationNames[operation.Name]; exists {
+			context.Report([]Loc{operation.Loc}, "Operation Name Uniqueness error: duplicate operation definition found: %s", operation.Name)
+		} else {
+			operationNames[operation.Name] = EXISTS
 		}
 	}
+}
 
-	// Operation Name Uniqueness
-	if _, exists := v.operationNames[operation.Name]; exists {
-		v.error("Operation Name Uniqueness error: duplicate operation definition found: %s", operation.Name)
-	} else {
-		v.operationNames[operation.Name] = EXISTS
+// LoneAnonymousOperation requires that no other operation is defined if any
+// anonymous (nameless) operation exists
+func LoneAnonymousOperation(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		if operation.Name == "" && len(context.Document.Operations) > 1 {
+			context.Report([]Loc{operation.Loc}, "Lone Anonymous Operation error: more than one operation defined with anonymous operation")
+		}
+	}
+}
+
+// OperationTypeExistence requires the Schema to declare a root type for the
+// type of every operation
+func OperationTypeExistence(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		if _, supported := operationRootType(operation, context.Schema); !supported {
+			context.Report([]Loc{operation.Loc}, "Operation Type error: schema does not support %s operations", operation.Type)
+		}
 	}
+}
+
+// SubscriptionSingleRootField requires every subscription operation to select
+// exactly one root field, including fields selected through fragments. The
+// introspection field __typename may not be selected as a root field, and is
+// not counted as one
+func SubscriptionSingleRootField(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		if operation.Type != "subscription" {
+			continue
+		}
+
+		var fields []Field
+		context.collectRootFields(operation.SelectionSet, &fields, make(map[string]struct{}))
+
+		responseKeys := make(map[string]struct{})
+		for _, field := range fields {
+			if field.Name == "__typename" {
+				context.Report([]Loc{field.Loc}, "Single Root Field error: subscription operation '%s' must not select __typename as a root field", operation.Name)
+				continue
+			}
+
+			if field.Alias != "" {
+				responseKeys[field.Alias] = EXISTS
+			} else {
+				responseKeys[field.Name] = EXISTS
+			}
+		}
 
-	if operation.Type == "query" {
-		queryRootType := v.schema.GetDeclaration(schema.DescribeType("QueryRoot"))
-		v.validateSelectionSet(queryRootType, operation.SelectionSet)
+		if len(responseKeys) != 1 {
+			context.Report([]Loc{operation.Loc}, "Single Root Field error: subscription operation '%s' must select exactly one root field", operation.Name)
+		}
 	}
 }
 
-func (v *validator) validateSelectionSet(selectionType schema.Declaration, selectionSet SelectionSet) {
-	switch d := selectionType.(type) {
+// Field Rules
+
+// FieldSelections requires every selected field to be defined by the type it is
+// selected from. Only __typename may be selected directly from a Union. Fields
+// of Object, Interface, and Union types must have a subselection, and fields of
+// Scalar and Enum types must not
+func FieldSelections(context *ValidationContext) {
+	context.Walk(Visitor{
+		SelectionSet: func(parentType schema.Declaration, selectionSet SelectionSet) {
+			switch d := parentType.(type) {
+			case schema.Interface, schema.Object, schema.Union:
+				if selectionSet.IsEmpty() {
+					context.Report([]Loc{selectionSet.Loc}, "Field Selection error: %s type '%s' must have a subselection", kindName(d), d.GetName())
+				}
+			case schema.Enum, schema.Scalar:
+				if !selectionSet.IsEmpty() {
+					context.Report([]Loc{selectionSet.Loc}, "Field Selection error: subselection not allowed on %s '%s'", kindName(d), d.GetName())
+				}
+			}
+		},
+		Field: func(parentType schema.Declaration, field Field, definition *schema.Field) {
+			if definition != nil {
+				return
+			}
+
+			if union, isUnion := parentType.(schema.Union); isUnion {
+				context.Report([]Loc{field.Loc}, "Field Selection error: cannot select non-metadata field from Union '%s'. Use fragment spreads to select fields from Union member types", union.Name)
+			} else {
+				context.Report([]Loc{field.Loc}, "Field Selection error: %s type '%s' does not contain the field '%s'", kindName(parentType), parentType.GetName(), field.Name)
+			}
+		},
+	})
+}
 
+// kindName returns the name of the kind of a type used in error messages
+func kindName(declaration schema.Declaration) string {
+	switch declaration.(type) {
+	case schema.Scalar:
+		return "Scalar"
+	case schema.Enum:
+		return "Enum"
+	case schema.Input:
+		return "Input"
 	case schema.Interface:
-		v.validateInterfaceSelectionSet(d, selectionSet)
+		return "Interface"
 	case schema.Object:
-		v.validateObjectSelectionSet(d, selectionSet)
+		return "Object"
 	case schema.Union:
-		v.validateUnionSelectionSet(d, selectionSet)
-	case schema.Enum:
-		if !selectionSet.IsEmpty() {
-			v.error("Field Selection error: subselection not allowed on Enum '%s'", selectionType.GetName())
+		return "Union"
+	}
+	panic("unreachable")
+}
+
+// OverlappingFieldsCanBeMerged requires fields selected with the same response
+// key, including those selected through fragments, to be mergeable into one
+// response value. They must return values of the same shape, and unless they
+// are selected from different Object types they must be the same field with
+// the same arguments. Fields are grouped by response key and each field is
+// compared with one representative of its group, so the rule runs in time
+// proportional to the size of the selections rather than comparing every pair.
+// A field collected more than once, as when a fragment is spread repeatedly, is
+// only compared once, and the same fields are never compared again at another
+// path, so nested fragments do not multiply the work
+func OverlappingFieldsCanBeMerged(context *ValidationContext) {
+	merger := fieldMerger{
+		context:   context,
+		fragments: make(map[string][]mergeField),
+		visiting:  make(map[string]struct{}),
+		reported:  make(map[[2]Loc]struct{}),
+		nodes:     make(map[*Selection]int),
+		checked:   make(map[string]struct{}),
+	}
+
+	for _, operation := range context.Document.Operations {
+		if rootType, supported := operationRootType(operation, context.Schema); supported {
+			merger.checkFields("", merger.collectFields(rootType, operation.SelectionSet, 0))
 		}
-	case schema.Scalar:
-		if !selectionSet.IsEmpty() {
-			v.error("Field Selection error: subselection not allowed on Scalar '%s'", selectionType.GetName())
+	}
+
+	// Fragments are checked on their own as well so that conflicts within them
+	// are reported even if they are not spread by any operation
+	for _, fragment := range context.Document.Fragments {
+		merger.checkFields("", merger.fragmentFields(fragment.Name))
+	}
+}
+
+// mergeField is a field selected from parentType, which is nil if the type is
+// not defined by the Schema. Fields of the same group may be selected from the
+// same object and must therefore be the same field. node is the Selection of
+// the field in the Document, which is shared by every copy of a field collected
+// through the same fragment
+type mergeField struct {
+	node       *Selection
+	parentType schema.Declaration
+	field      Field
+	definition *schema.Field
+	group      int
+}
+
+// parentName returns the name of the field's parent type, or "" if the type is
+// not defined
+func (f mergeField) parentName() string {
+	if f.parentType == nil {
+		return ""
+	}
+	return f.parentType.GetName()
+}
+
+func (f mergeField) responseKey() string {
+	if f.field.Alias != "" {
+		return f.field.Alias
+	}
+	return f.field.Name
+}
+
+// fieldMerger checks that fields can be merged. The fields selected by each
+// fragment are collected once and reused wherever the fragment is spread.
+// nodes numbers the Selections of the collected fields, and checked holds the
+// keys of the sets of fields that have already been checked
+type fieldMerger struct {
+	context   *ValidationContext
+	fragments map[string][]mergeField
+	visiting  map[string]struct{}
+	reported  map[[2]Loc]struct{}
+	nodes     map[*Selection]int
+	checked   map[string]struct{}
+	groups    int
+}
+
+// collectFields returns the fields of the selection set, including those of
+// its fragments, in the given group
+func (merger *fieldMerger) collectFields(parentType schema.Declaration, selectionSet SelectionSet, group int) []mergeField {
+	var fields []mergeField
+	for i, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case Field:
+			var definition *schema.Field
+			if parentType != nil {
+				definition = merger.context.fieldDefinition(parentType, selection.Name)
+			}
+			fields = append(fields, mergeField{node: &selectionSet.Selections[i], parentType: parentType, field: selection, definition: definition, group: group})
+		case InlineFragment:
+			fragmentType := parentType
+			if selection.Type != "" {
+				fragmentType = merger.context.Schema.GetDeclaration(schema.DescribeType(selection.Type))
+			}
+			fields = append(fields, merger.collectFields(fragmentType, selection.SelectionSet, group)...)
+		case FragmentSpread:
+			for _, field := range merger.fragmentFields(selection.Name) {
+				field.group = group
+				fields = append(fields, field)
+			}
 		}
-	default:
-		panic("unreachable")
 	}
+	return fields
 }
 
-func (v *validator) validateField(selectionType schema.Declaration, field Field) {
-	v.validateSelectionSet(selectionType, field.SelectionSet)
+// fragmentFields returns the fields selected by the named fragment. Fragments
+// that spread themselves select no fields from the spread that forms the cycle
+func (merger *fieldMerger) fragmentFields(name string) []mergeField {
+	if fields, collected := merger.fragments[name]; collected {
+		return fields
+	}
+	if _, isVisiting := merger.visiting[name]; isVisiting {
+		return nil
+	}
 
-	//v.validateDirectives(field.Directives)
+	fragment, err := merger.context.Document.GetFragment(name)
+	if err != nil {
+		return nil
+	}
+
+	merger.visiting[name] = EXISTS
+	fragmentType := merger.context.Schema.GetDeclaration(schema.DescribeType(fragment.Type))
+	fields := merger.collectFields(fragmentType, fragment.SelectionSet, 0)
+	delete(merger.visiting, name)
+
+	merger.fragments[name] = fields
+	return fields
 }
 
-func (v *validator) validateInlineFragment(inlineFragment InlineFragment) {
-	if declaration := v.schema.GetDeclaration(schema.DescribeType(inlineFragment.Type)); declaration == nil {
-		v.error("Inline Fragment Spread Type Existence error: target type '%s' does not exist in the schema", inlineFragment.Type)
-	} else {
-		v.validateSelectionSet(declaration, inlineFragment.SelectionSet)
+// checkFields checks the fields of every response key of a selection. path is
+// the path of response keys to the selection. Fields that were already checked
+// together at another path are not checked again; any conflict between them has
+// been reported already
+func (merger *fieldMerger) checkFields(path string, fields []mergeField) {
+	fields = distinctFields(fields)
+	key := merger.fieldsKey(fields)
+	if _, checked := merger.checked[key]; checked {
+		return
 	}
+	merger.checked[key] = EXISTS
 
-	// TODO nested fragment validation
+	var responseKeys []string
+	fieldsByKey := make(map[string][]mergeField)
+	for _, field := range fields {
+		key := field.responseKey()
+		if _, exists := fieldsByKey[key]; !exists {
+			responseKeys = append(responseKeys, key)
+		}
+		fieldsByKey[key] = append(fieldsByKey[key], field)
+	}
+
+	for _, key := range responseKeys {
+		merger.checkResponseKey(path+key, fieldsByKey[key])
+	}
 }
 
-func (v *validator) validateFragmentSpread(fragmentSpread FragmentSpread) {
-	if _, err := v.document.GetFragment(fragmentSpread.Name); err != nil {
-		v.error("Fragment Spread error: Fragment '%s' is not defined", fragmentSpread.Name)
+// checkResponseKey checks that the fields selected with the same response key
+// can be merged, and then checks their merged subselections
+func (merger *fieldMerger) checkResponseKey(path string, fields []mergeField) {
+	conflict := false
+
+	// Response shapes are compared with the first field with a known type
+	var shaped *mergeField
+	for i := range fields {
+		if fields[i].definition == nil {
+			continue
+		}
+		if shaped == nil {
+			shaped = &fields[i]
+		} else if !merger.sameResponseShape(shaped.definition.Type, fields[i].definition.Type) {
+			conflict = true
+			merger.report(path, *shaped, fields[i], "they return conflicting types '%s' and '%s'", shaped.definition.Type, fields[i].definition.Type)
+		}
+	}
+
+	// Fields of a group selected from an Interface, a Union or an unknown type
+	// may be selected from the same object as every other field of the group,
+	// which then must all be the same field. Otherwise only the fields selected
+	// from the same Object type must be
+	abstractGroups := make(map[int]struct{})
+	for _, field := range fields {
+		if _, isObject := field.parentType.(schema.Object); !isObject {
+			abstractGroups[field.group] = EXISTS
+		}
+	}
+
+	type mergeClass struct {
+		group      int
+		objectName string
+	}
+	var classes []mergeClass
+	representatives := make(map[mergeClass]mergeField)
+	members := make(map[mergeClass][]mergeField)
+	for _, field := range fields {
+		class := mergeClass{group: field.group}
+		if _, isAbstract := abstractGroups[field.group]; !isAbstract {
+			class.objectName = field.parentType.GetName()
+		}
+		members[class] = append(members[class], field)
+
+		if representative, exists := representatives[class]; !exists {
+			classes = append(classes, class)
+			representatives[class] = field
+		} else if representative.field.Name != field.field.Name {
+			conflict = true
+			merger.report(path, representative, field, "'%s' and '%s' are different fields", representative.field.Name, field.field.Name)
+		} else if !sameArguments(representative.field.Arguments, field.field.Arguments) {
+			conflict = true
+			merger.report(path, representative, field, "they have differing arguments")
+		}
+	}
+
+	// The subselections of each class form a group of their own. Classes of
+	// the same fields share a group, as their subselections are the same
+	childGroups := make(map[string]int)
+	var children []mergeField
+	for _, class := range classes {
+		key := merger.fieldsKey(members[class])
+		group, exists := childGroups[key]
+		if !exists {
+			merger.groups++
+			group = merger.groups
+			childGroups[key] = group
+		}
+
+		for _, field := range members[class] {
+			if field.definition != nil {
+				childType := merger.context.Schema.GetDeclaration(field.definition.Type)
+				children = append(children, merger.collectFields(childType, field.field.SelectionSet, group)...)
+			}
+		}
+	}
+
+	// Subselections of conflicting fields are not compared to avoid reporting
+	// errors that follow from the conflict
+	if !conflict {
+		merger.checkFields(path+".", children)
 	}
-	// TODO valid parent type spread & nesred ty
 }
 
-func (v *validator) validateInterfaceSelectionSet(intrface schema.Interface, selectionSet SelectionSet) {
-	if selectionSet.IsEmpty() {
-		v.error("Field Selection error: Interface type '%s' must have a subselection", intrface.Name)
-	} else {
-		for _, selectedField := range selectionSet.Fields {
-			if actualField, exists := intrface.Fields[selectedField.Name]; exists {
-				v.validateField(v.schema.GetDeclaration(actualField.Type), selectedField)
-				v.validateArguments(actualField, selectedField)
-			} else {
-				v.error("Field Selection error: Interface type '%s' does not contain the field '%s'", intrface.Name, selectedField.Name)
+// distinctFields returns the fields without the copies of a field collected
+// more than once in the same group
+func distinctFields(fields []mergeField) []mergeField {
+	type fieldCopy struct {
+		node       *Selection
+		parentName string
+		group      int
+	}
+	seen := make(map[fieldCopy]struct{}, len(fields))
+	distinct := fields[:0:0]
+	for _, field := range fields {
+		key := fieldCopy{field.node, field.parentName(), field.group}
+		if _, exists := seen[key]; !exists {
+			seen[key] = EXISTS
+			distinct = append(distinct, field)
+		}
+	}
+	return distinct
+}
+
+// fieldsKey returns a key identifying a set of fields by their Selections,
+// parent types and the way they are grouped, but not by the numbers of their
+// groups. Sets of fields with the same key are checked in the same way
+func (merger *fieldMerger) fieldsKey(fields []mergeField) string {
+	var groups []int
+	members := make(map[int][]string)
+	for _, field := range fields {
+		node, exists := merger.nodes[field.node]
+		if !exists {
+			node = len(merger.nodes)
+			merger.nodes[field.node] = node
+		}
+
+		if _, exists := members[field.group]; !exists {
+			groups = append(groups, field.group)
+		}
+		members[field.group] = append(members[field.group], strconv.Itoa(node)+" "+field.parentName())
+	}
+
+	keys := make([]string, len(groups))
+	for i, group := range groups {
+		sort.Strings(members[group])
+		keys[i] = strings.Join(members[group], ",")
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ";")
+}
+
+// report reports a conflict between two fields, once for every pair of fields
+func (merger *fieldMerger) report(path string, a, b mergeField, format string, s ...interface{}) {
+	pair := [2]Loc{a.field.Loc, b.field.Loc}
+	if _, reported := merger.reported[pair]; reported {
+		return
+	}
+	merger.reported[pair] = EXISTS
+
+	reason := fmt.Sprintf(format, s...)
+	merger.context.Report([]Loc{a.field.Loc, b.field.Loc}, "Field Merge error: fields '%s' conflict because %s. Use different aliases on the fields to fetch both if this was intentional", path, reason)
+}
+
+// sameResponseShape returns true if values of the two types have the same
+// shape: the same wrapping list and non-null types, and the same Scalar or Enum
+// type for leaf values. Subselections of composite types are compared
+// separately
+func (merger *fieldMerger) sameResponseShape(a, b schema.Type) bool {
+	if a.NonNull || b.NonNull {
+		if !a.NonNull || !b.NonNull {
+			return false
+		}
+		a.NonNull = false
+		b.NonNull = false
+		return merger.sameResponseShape(a, b)
+	}
+
+	if a.List || b.List {
+		return a.List && b.List && merger.sameResponseShape(*a.SubType, *b.SubType)
+	}
+
+	switch merger.context.Schema.GetDeclaration(a).(type) {
+	case schema.Scalar, schema.Enum:
+		return a.Name == b.Name
+	}
+	switch merger.context.Schema.GetDeclaration(b).(type) {
+	case schema.Scalar, schema.Enum:
+		return a.Name == b.Name
+	}
+	return true
+}
+
+// sameArguments returns true if both sets of arguments give the same values to
+// the same arguments
+func sameArguments(a, b map[string]Argument) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for name, argument := range a {
+		other, exists := b[name]
+		if !exists || argument.Value.String() != other.Value.String() {
+			return false
+		}
+	}
+	return true
+}
+
+// Argument Rules
+
+// ArgumentNames requires every argument given to a field or directive to be
+// defined by the field or directive
+func ArgumentNames(context *ValidationContext) {
+	context.Walk(Visitor{
+		Field: func(parentType schema.Declaration, field Field, definition *schema.Field) {
+			if definition == nil {
+				return
+			}
+
+			for _, name := range sortedArgumentNames(field.Arguments) {
+				if _, exists := definition.Arguments[name]; !exists {
+					context.Report([]Loc{field.Arguments[name].Loc}, "Field Argument error: provided invalid argument '%s' to field '%s'", name, definition.Name)
+				}
+			}
+		},
+	})
+
+	context.walkDirectives(func(directives []Directive, location schema.DirectiveLocation) {
+		for _, directive := range directives {
+			definition, exists := context.Schema.GetDirective(directive.Name)
+			if !exists {
+				continue
+			}
+
+			for _, name := range sortedArgumentNames(directive.Arguments) {
+				if _, exists := definition.Arguments[name]; !exists {
+					context.Report([]Loc{directive.Arguments[name].Loc}, "Directive Argument error: provided invalid argument '%s' to directive '@%s'", name, directive.Name)
+				}
+			}
+		}
+	})
+}
+
+// ArgumentValues requires the value given to every argument of a field or
+// directive to be a valid value of the argument's type: enum values must be
+// members of the Enum, input objects may only contain the fields declared by the
+// Input and must contain its required fields, lists are checked item by item,
+// and scalar literals must be accepted by the Scalar's ParseLiteral function
+func ArgumentValues(context *ValidationContext) {
+	context.Walk(Visitor{
+		Field: func(parentType schema.Declaration, field Field, definition *schema.Field) {
+			if definition != nil {
+				context.checkArgumentValues(field.Arguments, definition.Arguments, fmt.Sprintf("field '%s'", definition.Name))
+			}
+		},
+	})
+
+	context.walkDirectives(func(directives []Directive, location schema.DirectiveLocation) {
+		for _, directive := range directives {
+			if definition, exists := context.Schema.GetDirective(directive.Name); exists {
+				context.checkArgumentValues(directive.Arguments, definition.Arguments, fmt.Sprintf("directive '@%s'", directive.Name))
+			}
+		}
+	})
+}
+
+// checkArgumentValues reports the arguments whose values are invalid for their
+// definitions. Undefined arguments are left to ArgumentNames
+func (context *ValidationContext) checkArgumentValues(arguments map[string]Argument, definitions map[string]schema.Argument, owner string) {
+	for _, name := range sortedArgumentNames(arguments) {
+		definition, exists := definitions[name]
+		if !exists {
+			continue
+		}
+
+		if err := context.checkLiteral(arguments[name].Value, definition.Type); err != nil {
+			context.Report([]Loc{arguments[name].Value.GetLoc()}, "Argument Value error: argument '%s' of %s has invalid value: %s", name, owner, err)
+		}
+	}
+}
+
+// RequiredArguments requires every argument of a field or directive that has a
+// non-null type and no default value to be given
+func RequiredArguments(context *ValidationContext) {
+	context.Walk(Visitor{
+		Field: func(parentType schema.Declaration, field Field, definition *schema.Field) {
+			if definition != nil {
+				context.checkRequiredArguments(field.Arguments, definition.Arguments, field.Loc, fmt.Sprintf("field '%s'", definition.Name))
+			}
+		},
+	})
+
+	context.walkDirectives(func(directives []Directive, location schema.DirectiveLocation) {
+		for _, directive := range directives {
+			if definition, exists := context.Schema.GetDirective(directive.Name); exists {
+				context.checkRequiredArguments(directive.Arguments, definition.Arguments, directive.Loc, fmt.Sprintf("directive '@%s'", directive.Name))
+			}
+		}
+	})
+}
+
+func (context *ValidationContext) checkRequiredArguments(arguments map[string]Argument, definitions map[string]schema.Argument, loc Loc, owner string) {
+	var names []string
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		definition := definitions[name]
+		if _, provided := arguments[name]; !provided && definition.Type.NonNull && definition.Default == nil {
+			context.Report([]Loc{loc}, "Required Argument error: argument '%s' of type '%s' is required by %s but was not provided", name, definition.Type, owner)
+		}
+	}
+}
+
+// Fragment Rules
+
+// FragmentNameUniqueness requires every fragment to have a unique name
+func FragmentNameUniqueness(context *ValidationContext) {
+	fragmentNames := make(map[string]struct{})
+	for _, fragment := range context.Document.Fragments {
+		if _, exists := fragmentNames[fragment.Name]; exists {
+			context.Report([]Loc{fragment.Loc}, "Fragment Name Uniqueness error: duplicate fragment definition found '%s'", fragment.Name)
+		} else {
+			fragmentNames[fragment.Name] = EXISTS
+		}
+	}
+}
+
+// FragmentSpreadTypeExistence requires the type condition of every fragment and
+// inline fragment to exist in the Schema
+func FragmentSpreadTypeExistence(context *ValidationContext) {
+	for _, fragment := range context.Document.Fragments {
+		if declaration := context.Schema.GetDeclaration(schema.DescribeType(fragment.Type)); declaration == nil {
+			context.Report([]Loc{fragment.Loc}, "Fragment Spread Type Existence error: target type '%s' does not exist in the schema", fragment.Type)
+		}
+	}
+
+	context.Walk(Visitor{
+		InlineFragment: func(parentType schema.Declaration, inlineFragment InlineFragment) {
+			if inlineFragment.Type == "" {
+				return
+			}
+
+			if declaration := context.Schema.GetDeclaration(schema.DescribeType(inlineFragment.Type)); declaration == nil {
+				context.Report([]Loc{inlineFragment.Loc}, "Inline Fragment Spread Type Existence error: target type '%s' does not exist in the schema", inlineFragment.Type)
+			}
+		},
+	})
+}
+
+// FragmentSpreadTargetDefined requires every fragment spread to refer to a
+// fragment defined in the Document
+func FragmentSpreadTargetDefined(context *ValidationContext) {
+	context.Walk(Visitor{
+		FragmentSpread: func(parentType schema.Declaration, fragmentSpread FragmentSpread) {
+			if _, err := context.Document.GetFragment(fragmentSpread.Name); err != nil {
+				context.Report([]Loc{fragmentSpread.Loc}, "Fragment Spread error: Fragment '%s' is not defined", fragmentSpread.Name)
 			}
+		},
+	})
+}
+
+// FragmentsMustBeUsed requires every fragment to be spread by an operation,
+// either directly or through other fragments
+func FragmentsMustBeUsed(context *ValidationContext) {
+	used := make(map[string]struct{})
+	var spread func(selectionSet SelectionSet)
+	spread = func(selectionSet SelectionSet) {
+		for _, fragmentSpread := range fragmentSpreads(selectionSet) {
+			if _, visited := used[fragmentSpread.Name]; visited {
+				continue
+			}
+			used[fragmentSpread.Name] = EXISTS
+
+			if fragment, err := context.Document.GetFragment(fragmentSpread.Name); err == nil {
+				spread(fragment.SelectionSet)
+			}
+		}
+	}
+
+	for _, operation := range context.Document.Operations {
+		spread(operation.SelectionSet)
+	}
+
+	for _, fragment := range context.Document.Fragments {
+		if _, isUsed := used[fragment.Name]; !isUsed {
+			context.Report([]Loc{fragment.Loc}, "Fragment Usage error: fragment '%s' is never used", fragment.Name)
 		}
+	}
+}
+
+// NoFragmentCycles requires that no fragment spreads itself, either directly or
+// through other fragments. Every cycle is reported once with the path of
+// fragments that forms it
+func NoFragmentCycles(context *ValidationContext) {
+	visited := make(map[string]struct{})
+
+	// The spreads followed from the fragment being checked, and the index in
+	// spreadPath at which each fragment on the path was entered
+	var spreadPath []FragmentSpread
+	pathIndex := make(map[string]int)
 
-		for _, inlineFragment := range selectionSet.InlineFragments {
-			v.validateInlineFragment(inlineFragment)
+	var detectCycles func(fragment Fragment)
+	detectCycles = func(fragment Fragment) {
+		if _, isVisited := visited[fragment.Name]; isVisited {
+			return
 		}
+		visited[fragment.Name] = EXISTS
 
-		for _, fragmentSpread := range selectionSet.FragmentSpreads {
-			v.validateFragmentSpread(fragmentSpread)
+		pathIndex[fragment.Name] = len(spreadPath)
+		for _, fragmentSpread := range fragmentSpreads(fragment.SelectionSet) {
+			spreadPath = append(spreadPath, fragmentSpread)
+			if index, onPath := pathIndex[fragmentSpread.Name]; onPath {
+				cycle := spreadPath[index:]
+				names := []string{fragmentSpread.Name}
+				var locations []Loc
+				for _, cycleSpread := range cycle {
+					names = append(names, cycleSpread.Name)
+					locations = append(locations, cycleSpread.Loc)
+				}
+				context.Report(locations, "Fragment Cycle error: fragment '%s' spreads itself through %s", fragmentSpread.Name, strings.Join(names, " -> "))
+			} else if target, err := context.Document.GetFragment(fragmentSpread.Name); err == nil {
+				detectCycles(target)
+			}
+			spreadPath = spreadPath[:len(spreadPath)-1]
 		}
+		delete(pathIndex, fragment.Name)
+	}
+
+	for _, fragment := range context.Document.Fragments {
+		detectCycles(fragment)
+	}
+}
+
+// FragmentSpreadIsPossible requires every fragment and inline fragment to be
+// spread only into types that share at least one possible Object type with the
+// fragment's type condition
+func FragmentSpreadIsPossible(context *ValidationContext) {
+	context.Walk(Visitor{
+		InlineFragment: func(parentType schema.Declaration, inlineFragment InlineFragment) {
+			if inlineFragment.Type == "" {
+				return
+			}
+
+			fragmentType := context.Schema.GetDeclaration(schema.DescribeType(inlineFragment.Type))
+			if fragmentType != nil && !context.typesOverlap(parentType, fragmentType) {
+				context.Report([]Loc{inlineFragment.Loc}, "Inline Fragment Spread error: fragment on type '%s' can never be spread into type '%s'", inlineFragment.Type, parentType.GetName())
+			}
+		},
+		FragmentSpread: func(parentType schema.Declaration, fragmentSpread FragmentSpread) {
+			fragment, err := context.Document.GetFragment(fragmentSpread.Name)
+			if err != nil {
+				return
+			}
+
+			fragmentType := context.Schema.GetDeclaration(schema.DescribeType(fragment.Type))
+			if fragmentType != nil && !context.typesOverlap(parentType, fragmentType) {
+				context.Report([]Loc{fragmentSpread.Loc}, "Fragment Spread error: fragment '%s' on type '%s' can never be spread into type '%s'", fragment.Name, fragment.Type, parentType.GetName())
+			}
+		},
+	})
+}
+
+// typesOverlap returns true if an Object type is a possible type of both types
+func (context *ValidationContext) typesOverlap(a, b schema.Declaration) bool {
+	possible := make(map[string]struct{})
+	for _, name := range context.possibleTypes(a) {
+		possible[name] = EXISTS
 	}
+
+	for _, name := range context.possibleTypes(b) {
+		if _, exists := possible[name]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// possibleTypes returns the names of the Object types a value of the type may
+// be: the Object itself, the Objects implementing an Interface, or the members
+// of a Union
+func (context *ValidationContext) possibleTypes(declaration schema.Declaration) []string {
+	switch d := declaration.(type) {
+	case schema.Object:
+		return []string{d.Name}
+	case schema.Interface:
+		return context.Schema.GetObjectsThatImplement(d.Name)
+	case schema.Union:
+		return d.Types
+	}
+	return nil
+}
+
+// fragmentSpreads returns the fragment spreads within a selection set,
+// including those nested in fields and inline fragments, but not those of the
+// spread fragments themselves
+func fragmentSpreads(selectionSet SelectionSet) []FragmentSpread {
+	var spreads []FragmentSpread
+	for _, selection := range selectionSet.Selections {
+		switch selection := selection.(type) {
+		case Field:
+			spreads = append(spreads, fragmentSpreads(selection.SelectionSet)...)
+		case InlineFragment:
+			spreads = append(spreads, fragmentSpreads(selection.SelectionSet)...)
+		case FragmentSpread:
+			spreads = append(spreads, selection)
+		}
+	}
+	return spreads
+}
+
+// Directive Rules
+
+// walkDirectives calls visit with the directives of every node in the Document
+// that can be annotated, and the location of the node
+func (context *ValidationContext) walkDirectives(visit func(directives []Directive, location schema.DirectiveLocation)) {
+	var walkSelectionSet func(selectionSet SelectionSet)
+	walkSelectionSet = func(selectionSet SelectionSet) {
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case Field:
+				visit(selection.Directives, schema.LocationField)
+				walkSelectionSet(selection.SelectionSet)
+			case InlineFragment:
+				visit(selection.Directives, schema.LocationInlineFragment)
+				walkSelectionSet(selection.SelectionSet)
+			case FragmentSpread:
+				visit(selection.Directives, schema.LocationFragmentSpread)
+			}
+		}
+	}
+
+	for _, operation := range context.Document.Operations {
+		switch operation.Type {
+		case "mutation":
+			visit(operation.Directives, schema.LocationMutation)
+		case "subscription":
+			visit(operation.Directives, schema.LocationSubscription)
+		default:
+			visit(operation.Directives, schema.LocationQuery)
+		}
+
+		for _, varDef := range operation.VariableDefinitions {
+			visit(varDef.Directives, schema.LocationVariableDefinition)
+		}
+		walkSelectionSet(operation.SelectionSet)
+	}
+
+	for _, fragment := range context.Document.Fragments {
+		visit(fragment.Directives, schema.LocationFragmentDefinition)
+		walkSelectionSet(fragment.SelectionSet)
+	}
+}
+
+// DirectivesDefined requires every directive used in the Document to be
+// declared by the Schema
+func DirectivesDefined(context *ValidationContext) {
+	context.walkDirectives(func(directives []Directive, location schema.DirectiveLocation) {
+		for _, directive := range directives {
+			if _, exists := context.Schema.GetDirective(directive.Name); !exists {
+				context.Report([]Loc{directive.Loc}, "Directive error: unknown directive '@%s'", directive.Name)
+			}
+		}
+	})
+}
+
+// DirectivesInValidLocations requires every directive to be used only at the
+// locations its declaration allows
+func DirectivesInValidLocations(context *ValidationContext) {
+	context.walkDirectives(func(directives []Directive, location schema.DirectiveLocation) {
+		for _, directive := range directives {
+			if definition, exists := context.Schema.GetDirective(directive.Name); exists && !definition.AllowsLocation(location) {
+				context.Report([]Loc{directive.Loc}, "Directive error: directive '@%s' may not be used on %s", directive.Name, location)
+			}
+		}
+	})
 }
 
-func (v *validator) validateObjectSelectionSet(object schema.Object, selectionSet SelectionSet) {
-	if selectionSet.IsEmpty() {
-		v.error("Field Selection error: Object type '%s' must have a subselection", object.Name)
-	} else {
-		for _, selectedField := range selectionSet.Fields {
-			if actualField, exists := object.Fields[selectedField.Name]; exists {
-				v.validateField(v.schema.GetDeclaration(actualField.Type), selectedField)
-				v.validateArguments(actualField, selectedField)
+// DirectivesUniquePerLocation requires a directive to be used at most once at
+// each location, unless it is declared repeatable
+func DirectivesUniquePerLocation(context *ValidationContext) {
+	context.walkDirectives(func(directives []Directive, location schema.DirectiveLocation) {
+		used := make(map[string]Loc)
+		for _, directive := range directives {
+			if definition, exists := context.Schema.GetDirective(directive.Name); exists && definition.Repeatable {
+				continue
+			}
+
+			if first, exists := used[directive.Name]; exists {
+				context.Report([]Loc{first, directive.Loc}, "Directive error: directive '@%s' may only be used once at this location", directive.Name)
 			} else {
-				v.error("Field Selection error: Object type '%s' does not contain the field '%s'", object.Name, selectedField.Name)
+				used[directive.Name] = directive.Loc
 			}
 		}
+	})
+}
+
+// Variable Rules
 
-		for _, inlineFragment := range selectionSet.InlineFragments {
-			v.validateInlineFragment(inlineFragment)
+// VariableUniqueness requires the variables defined by an operation to have
+// unique names
+func VariableUniqueness(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		defined := make(map[string]struct{})
+		for _, definition := range operation.VariableDefinitions {
+			if _, exists := defined[definition.Name]; exists {
+				context.Report([]Loc{definition.Loc}, "Variable Uniqueness error: duplicate variable definition found '$%s'", definition.Name)
+			} else {
+				defined[definition.Name] = EXISTS
+			}
 		}
+	}
+}
 
-		for _, fragmentSpread := range selectionSet.FragmentSpreads {
-			v.validateFragmentSpread(fragmentSpread)
+// VariablesAreInputTypes requires the type of every variable to be a Scalar,
+// Enum, or Input type declared by the Schema
+func VariablesAreInputTypes(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		for _, definition := range operation.VariableDefinitions {
+			t := schema.DescribeASTType(definition.Type)
+			switch declaration := context.Schema.GetDeclaration(t).(type) {
+			case nil:
+				context.Report([]Loc{definition.Type.Loc}, "Variable Type error: variable '$%s' has unknown type '%s'", definition.Name, t)
+			case schema.Scalar, schema.Enum, schema.Input:
+			default:
+				context.Report([]Loc{definition.Type.Loc}, "Variable Type error: variable '$%s' cannot be of non-input %s type '%s'", definition.Name, kindName(declaration), t)
+			}
 		}
 	}
 }
 
-func (v *validator) validateUnionSelectionSet(union schema.Union, selectionSet SelectionSet) {
-	if selectionSet.IsEmpty() {
-		v.error("Field Selection error: Union type '%s' must have a subselection", union.Name)
-	} else {
-		for _, selectedField := range selectionSet.Fields {
-			if selectedField.Name != "__typename" {
-				v.error("Field Selection error: cannot select non-metadata field from Union '%s'. Use fragment spreads to select fields from Union member types", union.Name)
+// VariableDefaultValuesAllowed requires the default value of every variable to
+// be a constant value of the variable's type
+func VariableDefaultValuesAllowed(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		for _, definition := range operation.VariableDefinitions {
+			if definition.Default == nil {
+				continue
+			}
+
+			if variable, isConstant := constantValue(definition.Default); !isConstant {
+				context.Report([]Loc{variable.Loc}, "Variable Default Value error: default value of variable '$%s' cannot use variable '$%s'", definition.Name, variable.Name)
+			} else if err := context.checkLiteral(definition.Default, schema.DescribeASTType(definition.Type)); err != nil {
+				context.Report([]Loc{definition.Default.GetLoc()}, "Variable Default Value error: variable '$%s' has invalid default value: %s", definition.Name, err)
 			}
 		}
+	}
+}
 
-		for _, inlineFragment := range selectionSet.InlineFragments {
-			v.validateInlineFragment(inlineFragment)
+// AllVariableUsesDefined requires every variable used by an operation,
+// including variables used by the fragments it spreads, to be defined by the
+// operation
+func AllVariableUsesDefined(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		defined := make(map[string]struct{})
+		for _, definition := range operation.VariableDefinitions {
+			defined[definition.Name] = EXISTS
 		}
 
-		for _, fragmentSpread := range selectionSet.FragmentSpreads {
-			v.validateFragmentSpread(fragmentSpread)
+		for _, variable := range context.variableUsages(operation) {
+			if _, exists := defined[variable.Name]; !exists {
+				context.Report([]Loc{variable.Loc, operation.Loc}, "Variable error: variable '$%s' is not defined by %s", variable.Name, describeOperation(operation))
+			}
 		}
 	}
 }
 
-func (v *validator) validateArguments(field schema.Field, selectedField Field) {
-	for argName := range selectedField.Arguments {
-		if _, exists := field.Arguments[argName]; !exists {
-			v.error("Field Argument error: provided invalid argument '%s' to field '%s'", argName, field.Name)
+// AllVariablesUsed requires every variable defined by an operation to be used
+// by the operation or by the fragments it spreads
+func AllVariablesUsed(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		used := make(map[string]struct{})
+		for _, variable := range context.variableUsages(operation) {
+			used[variable.Name] = EXISTS
+		}
+
+		for _, definition := range operation.VariableDefinitions {
+			if _, exists := used[definition.Name]; !exists {
+				context.Report([]Loc{definition.Loc}, "Variable error: variable '$%s' is never used by %s", definition.Name, describeOperation(operation))
+			}
 		}
 	}
 }
 
-// Fragment Rules
-//  Fragment Name Uniqueness
-//    Every fragment must have a unique name
-//  Fragment Spread Type Existence TODO must validated for inline fragments as well
-//    The "on" type for the Fragment definition must exist in the Schema
-//
-func (v *validator) validateFragment(fragment Fragment) {
-	// Fragment Name Uniqueness
-	if _, exists := v.fragmentNames[fragment.Name]; exists {
-		v.error("Fragment Name Uniqueness error: duplicate fragment definition found '%s'", fragment.Name)
-	} else {
-		v.fragmentNames[fragment.Name] = EXISTS
+// VariablesInAllowedPosition requires every variable to be used only at
+// positions whose type is compatible with the variable's type. A nullable
+// variable may be used at a non-null position only if either the variable or
+// the position has a default value
+func VariablesInAllowedPosition(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		definitions := make(map[string]VariableDefinition)
+		for _, definition := range operation.VariableDefinitions {
+			if _, exists := definitions[definition.Name]; !exists {
+				definitions[definition.Name] = definition
+			}
+		}
+
+		for _, usage := range context.typedVariableUsages(operation) {
+			definition, defined := definitions[usage.variable.Name]
+			if !defined {
+				continue
+			}
+
+			variableType := schema.DescribeASTType(definition.Type)
+			if !isVariableUsageAllowed(definition, variableType, usage) {
+				context.Report([]Loc{definition.Loc, usage.variable.Loc}, "Variable error: variable '$%s' of type '%s' cannot be used in a position expecting type '%s'", definition.Name, variableType, usage.t)
+			}
+		}
+	}
+}
+
+// isVariableUsageAllowed returns true if a variable may be used at the
+// position of the usage
+func isVariableUsageAllowed(definition VariableDefinition, variableType schema.Type, usage variableUsage) bool {
+	if usage.t.NonNull && !variableType.NonNull {
+		_, nullDefault := definition.Default.(NullValue)
+		hasNonNullDefault := definition.Default != nil && !nullDefault
+		if !hasNonNullDefault && !usage.hasDefault {
+			return false
+		}
+
+		nullableType := usage.t
+		nullableType.NonNull = false
+		return areTypesCompatible(variableType, nullableType)
 	}
+	return areTypesCompatible(variableType, usage.t)
+}
 
-	// Fragment Spread Type Existence
-	if declaration := v.schema.GetDeclaration(schema.DescribeType(fragment.Type)); declaration == nil {
-		v.error("Fragment Spread Type Existence error: target type '%s' does not exist in the schema", fragment.Type)
-	} else {
-		v.validateSelectionSet(declaration, fragment.SelectionSet)
+// areTypesCompatible returns true if a value of the variable's type is always
+// a valid value of the location's type
+func areTypesCompatible(variableType, locationType schema.Type) bool {
+	if locationType.NonNull {
+		if !variableType.NonNull {
+			return false
+		}
+		variableType.NonNull = false
+		locationType.NonNull = false
+		return areTypesCompatible(variableType, locationType)
 	}
 
-	//v.validateDirectives(fragment.Directives)
+	if variableType.NonNull {
+		variableType.NonNull = false
+		return areTypesCompatible(variableType, locationType)
+	}
+
+	if locationType.List || variableType.List {
+		return locationType.List && variableType.List && areTypesCompatible(*variableType.SubType, *locationType.SubType)
+	}
+	return variableType.Name == locationType.Name
 }
 
-func (v *validator) error(format string, s ...interface{}) {
-	v.errors = append(v.errors, fmt.Errorf(format, s...))
+// variableUsage is a use of a variable at a position expecting a value of type
+// t, such as an argument or an input object field
+type variableUsage struct {
+	variable   Variable
+	t          schema.Type
+	hasDefault bool // The position has a default value used when the variable is not provided
+}
+
+// typedVariableUsages returns the variables used by an operation, including
+// those used by the fragments it spreads, with the types of the positions they
+// are used at. Variables used at positions of unknown types are left out
+func (context *ValidationContext) typedVariableUsages(operation Operation) (usages []variableUsage) {
+	var collectValue func(value Value, t schema.Type, hasDefault bool)
+	collectValue = func(value Value, t schema.Type, hasDefault bool) {
+		switch v := value.(type) {
+		case Variable:
+			usages = append(usages, variableUsage{variable: v, t: t, hasDefault: hasDefault})
+		case ListValue:
+			if t.List {
+				for _, item := range v.Values {
+					collectValue(item, *t.SubType, false)
+				}
+			}
+		case ObjectValue:
+			if input, isInput := context.Schema.GetDeclaration(t).(schema.Input); isInput && !t.List {
+				for _, name := range sortedObjectFieldNames(v.Fields) {
+					if field, exists := input.Fields[name]; exists {
+						collectValue(v.Fields[name].Value, field.Type, false)
+					}
+				}
+			}
+		}
+	}
+
+	collectArguments := func(arguments map[string]Argument, definitions map[string]schema.Argument) {
+		for _, name := range sortedArgumentNames(arguments) {
+			if argument, exists := definitions[name]; exists {
+				collectValue(arguments[name].Value, argument.Type, argument.Default != nil)
+			}
+		}
+	}
+
+	collectDirectives := func(directives []Directive) {
+		for _, directive := range directives {
+			if definition, exists := context.Schema.GetDirective(directive.Name); exists {
+				collectArguments(directive.Arguments, definition.Arguments)
+			}
+		}
+	}
+
+	visitedFragments := make(map[string]struct{})
+	var visitor Visitor
+	visitor = Visitor{
+		Field: func(parentType schema.Declaration, field Field, definition *schema.Field) {
+			if definition != nil {
+				collectArguments(field.Arguments, definition.Arguments)
+			}
+			collectDirectives(field.Directives)
+		},
+		InlineFragment: func(parentType schema.Declaration, inlineFragment InlineFragment) {
+			collectDirectives(inlineFragment.Directives)
+		},
+		FragmentSpread: func(parentType schema.Declaration, fragmentSpread FragmentSpread) {
+			collectDirectives(fragmentSpread.Directives)
+			if _, visited := visitedFragments[fragmentSpread.Name]; visited {
+				return
+			}
+			visitedFragments[fragmentSpread.Name] = EXISTS
+
+			fragment, err := context.Document.GetFragment(fragmentSpread.Name)
+			if err != nil {
+				return
+			}
+			collectDirectives(fragment.Directives)
+			if fragmentType := context.Schema.GetDeclaration(schema.DescribeType(fragment.Type)); fragmentType != nil {
+				context.walkSelectionSet(visitor, fragmentType, fragment.SelectionSet)
+			}
+		},
+	}
+
+	collectDirectives(operation.Directives)
+	if rootType, supported := operationRootType(operation, context.Schema); supported {
+		context.walkSelectionSet(visitor, rootType, operation.SelectionSet)
+	}
+	return usages
+}
+
+// describeOperation describes an operation in error messages
+func describeOperation(operation Operation) string {
+	if operation.Name == "" {
+		return "the anonymous operation"
+	}
+	return fmt.Sprintf("operation '%s'", operation.Name)
+}
+
+// variableUsages returns every variable used in the arguments of an operation's
+// fields and directives, including those of the fragments the operation
+// spreads. Variables are returned in the order they are used
+func (context *ValidationContext) variableUsages(operation Operation) (usages []Variable) {
+	var collectValue func(value Value)
+	collectValue = func(value Value) {
+		switch v := value.(type) {
+		case Variable:
+			usages = append(usages, v)
+		case ListValue:
+			for _, item := range v.Values {
+				collectValue(item)
+			}
+		case ObjectValue:
+			for _, name := range sortedObjectFieldNames(v.Fields) {
+				collectValue(v.Fields[name].Value)
+			}
+		}
+	}
+
+	collectArguments := func(arguments map[string]Argument) {
+		for _, name := range sortedArgumentNames(arguments) {
+			collectValue(arguments[name].Value)
+		}
+	}
+
+	collectDirectives := func(directives []Directive) {
+		for _, directive := range directives {
+			collectArguments(directive.Arguments)
+		}
+	}
+
+	visitedFragments := make(map[string]struct{})
+	var collectSelectionSet func(selectionSet SelectionSet)
+	collectSelectionSet = func(selectionSet SelectionSet) {
+		for _, selection := range selectionSet.Selections {
+			switch selection := selection.(type) {
+			case Field:
+				collectArguments(selection.Arguments)
+				collectDirectives(selection.Directives)
+				collectSelectionSet(selection.SelectionSet)
+			case InlineFragment:
+				collectDirectives(selection.Directives)
+				collectSelectionSet(selection.SelectionSet)
+			case FragmentSpread:
+				collectDirectives(selection.Directives)
+				if _, visited := visitedFragments[selection.Name]; visited {
+					continue
+				}
+				visitedFragments[selection.Name] = EXISTS
+
+				if fragment, err := context.Document.GetFragment(selection.Name); err == nil {
+					collectDirectives(fragment.Directives)
+					collectSelectionSet(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+
+	collectDirectives(operation.Directives)
+	collectSelectionSet(operation.SelectionSet)
+	return usages
+}
+
+// constantValue returns false and the first variable used by a value if it is
+// not constant
+func constantValue(value Value) (Variable, bool) {
+	switch v := value.(type) {
+	case Variable:
+		return v, false
+	case ListValue:
+		for _, item := range v.Values {
+			if variable, isConstant := constantValue(item); !isConstant {
+				return variable, false
+			}
+		}
+	case ObjectValue:
+		for _, name := range sortedObjectFieldNames(v.Fields) {
+			if variable, isConstant := constantValue(v.Fields[name].Value); !isConstant {
+				return variable, false
+			}
+		}
+	}
+	return Variable{}, true
+}
+
+// checkLiteral returns an error if a value literal is not a valid value of the
+// type t. Variables are not checked, and neither are values of types the Schema
+// does not declare, since they are reported by other rules
+func (context *ValidationContext) checkLiteral(value Value, t schema.Type) error {
+	switch value.(type) {
+	case Variable:
+		return nil
+	case NullValue:
+		if t.NonNull {
+			return fmt.Errorf("expected non-null value of type '%s' but found null", t)
+		}
+		return nil
+	}
+
+	if t.List {
+		list, isList := value.(ListValue)
+
+		// A single value is coerced to a list containing only that value
+		if !isList {
+			return context.checkLiteral(value, *t.SubType)
+		}
+
+		for _, item := range list.Values {
+			if err := context.checkLiteral(item, *t.SubType); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch declaration := context.Schema.GetDeclaration(t).(type) {
+	case nil:
+		return nil
+	case schema.Scalar:
+		if _, err := schema.ParseScalarLiteral(declaration, value, nil); err == nil {
+			return nil
+		}
+	case schema.Enum:
+		if enumValue, isEnum := value.(EnumValue); isEnum {
+			if _, exists := declaration.GetValue(enumValue.Value); exists {
+				return nil
+			}
+		}
+	case schema.Input:
+		object, isObject := value.(ObjectValue)
+		if !isObject {
+			break
+		}
+
+		for _, name := range sortedObjectFieldNames(object.Fields) {
+			field, exists := declaration.Fields[name]
+			if !exists {
+				return fmt.Errorf("field '%s' is not defined by type '%s'", name, declaration.Name)
+			}
+			if err := context.checkLiteral(object.Fields[name].Value, field.Type); err != nil {
+				return err
+			}
+		}
+
+		var required []string
+		for name, field := range declaration.Fields {
+			if _, provided := object.Fields[name]; !provided && field.Type.NonNull {
+				required = append(required, name)
+			}
+		}
+		if len(required) > 0 {
+			sort.Strings(required)
+			return fmt.Errorf("field '%s' of required type '%s' was not provided", required[0], declaration.Fields[required[0]].Type)
+		}
+		return nil
+	}
+	return fmt.Errorf("expected value of type '%s' but found %s", t, value)
+}
+
+// Deprecation Rules
+
+// DeprecatedUsage reports every use of a deprecated field, argument, input
+// field, or enum value in the Document, including the values given to
+// directive arguments and the default values of variables. Using deprecated
+// parts of a Schema is allowed, so DeprecatedUsage is not one of the SpecRules;
+// its errors are meant to be shown as warnings
+func DeprecatedUsage(context *ValidationContext) {
+	context.Walk(Visitor{
+		Field: func(parentType schema.Declaration, field Field, definition *schema.Field) {
+			if definition == nil {
+				return
+			}
+
+			if definition.DeprecationReason != "" {
+				context.Report([]Loc{field.Loc}, "Deprecation warning: field '%s.%s' is deprecated: %s", parentType.GetName(), definition.Name, definition.DeprecationReason)
+			}
+			context.reportDeprecatedArguments(field.Arguments, definition.Arguments, fmt.Sprintf("field '%s.%s'", parentType.GetName(), definition.Name))
+		},
+	})
+
+	context.walkDirectives(func(directives []Directive, location schema.DirectiveLocation) {
+		for _, directive := range directives {
+			if definition, exists := context.Schema.GetDirective(directive.Name); exists {
+				context.reportDeprecatedArguments(directive.Arguments, definition.Arguments, fmt.Sprintf("directive '@%s'", directive.Name))
+			}
+		}
+	})
+
+	for _, operation := range context.Document.Operations {
+		for _, definition := range operation.VariableDefinitions {
+			if definition.Default != nil {
+				context.reportDeprecatedValues(definition.Default, schema.DescribeASTType(definition.Type))
+			}
+		}
+	}
+}
+
+// FindDeprecatedUsages returns a warning for every use of a deprecated part of
+// the Schema in the Document
+func FindDeprecatedUsages(schema *schema.Schema, document *Document) []*ValidationError {
+	return Validate(schema, document, DeprecatedUsage)
+}
+
+// reportDeprecatedArguments reports the deprecated arguments given to the
+// field or directive described by owner, and the deprecated input fields and
+// enum values used by their values. Undefined arguments are left to
+// ArgumentNames
+func (context *ValidationContext) reportDeprecatedArguments(arguments map[string]Argument, definitions map[string]schema.Argument, owner string) {
+	for _, name := range sortedArgumentNames(arguments) {
+		definition, exists := definitions[name]
+		if !exists {
+			continue
+		}
+
+		given := arguments[name]
+		if definition.DeprecationReason != "" {
+			context.Report([]Loc{given.Loc}, "Deprecation warning: argument '%s' of %s is deprecated: %s", name, owner, definition.DeprecationReason)
+		}
+		context.reportDeprecatedValues(given.Value, definition.Type)
+	}
+}
+
+// reportDeprecatedValues reports the deprecated input fields and enum values
+// used by a value literal of the given type
+func (context *ValidationContext) reportDeprecatedValues(value Value, t schema.Type) {
+	if t.List {
+		if list, isList := value.(ListValue); isList {
+			for _, item := range list.Values {
+				context.reportDeprecatedValues(item, *t.SubType)
+			}
+		} else {
+			// A single value is coerced into a list of one item
+			context.reportDeprecatedValues(value, *t.SubType)
+		}
+		return
+	}
+
+	switch d := context.Schema.GetDeclaration(schema.DescribeType(t.Name)).(type) {
+	case schema.Enum:
+		if literal, isEnumValue := value.(EnumValue); isEnumValue {
+			if enumValue, exists := d.GetValue(literal.Value); exists && enumValue.DeprecationReason != "" {
+				context.Report([]Loc{literal.Loc}, "Deprecation warning: enum value '%s.%s' is deprecated: %s", d.Name, enumValue.Name, enumValue.DeprecationReason)
+			}
+		}
+	case schema.Input:
+		object, isObject := value.(ObjectValue)
+		if !isObject {
+			return
+		}
+
+		for _, name := range sortedObjectFieldNames(object.Fields) {
+			field, exists := d.Fields[name]
+			if !exists {
+				continue
+			}
+
+			if field.DeprecationReason != "" {
+				context.Report([]Loc{object.Fields[name].Loc}, "Deprecation warning: input field '%s.%s' is deprecated: %s", d.Name, name, field.DeprecationReason)
+			}
+			context.reportDeprecatedValues(object.Fields[name].Value, field.Type)
+		}
+	}
+}
+
+// sortedArgumentNames returns the names of the given arguments in sorted
+// order, so they are reported in a consistent order
+func sortedArgumentNames(arguments map[string]Argument) []string {
+	names := make([]string, 0, len(arguments))
+	for name := range arguments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedObjectFieldNames returns the names of the fields of an ObjectValue in
+// sorted order, so they are reported in a consistent order
+func sortedObjectFieldNames(fields map[string]ObjectField) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
@@ -2,8 +2,10 @@ package graphql
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	schema "github.com/WilsonGiese/graphql/schema"
 )
@@ -24,7 +26,7 @@ func Test(t *testing.T) {
 		document, parseErr := Parse(tokens)
 		fmt.Printf("%+v\n", document)
 		if parseErr == nil {
-			errors := validate(SampleSchema, &document)
+			errors := Validate(SampleSchema, &document)
 			if len(errors) > 0 {
 				panic(fmt.Sprintf("%v", errors))
 			}
@@ -41,7 +43,11 @@ func init() {
 		Declare(schema.Enum{
 			Description: "Commands that a Dog may know",
 			Name:        "DogCommand",
-			Values:      schema.Values("SIT", "DOWN", "HEEL"),
+			Values: []schema.EnumValue{
+				{Name: "SIT"},
+				{Name: "DOWN"},
+				{Name: "HEEL", DeprecationReason: "Dogs no longer heel"},
+			},
 		}).
 		Declare(schema.Enum{
 			Description: "Commands that a Cat may know",
@@ -107,9 +113,10 @@ func init() {
 					Type:        schema.NonNullStringType,
 				},
 				schema.Field{
-					Description: "Nickname of this Dog",
-					Name:        "nickname",
-					Type:        schema.StringType,
+					Description:       "Nickname of this Dog",
+					Name:              "nickname",
+					Type:              schema.StringType,
+					DeprecationReason: "Use name",
 				},
 				schema.Field{
 					Description: "How loud this Dog will bark",
@@ -133,8 +140,9 @@ func init() {
 					Type:        schema.NonNullBooleanType,
 					Arguments: schema.Arguments(
 						schema.Argument{
-							Name: "atOtherHomes",
-							Type: schema.BooleanType,
+							Name:              "atOtherHomes",
+							Type:              schema.BooleanType,
+							DeprecationReason: "Dogs are house trained everywhere",
 						},
 					),
 				},
@@ -201,6 +209,488 @@ func init() {
 					Name: "dog",
 					Type: schema.DescribeType("Dog"),
 				},
+				schema.Field{
+					Name: "findDog",
+					Type: schema.DescribeType("Dog"),
+					Arguments: schema.Arguments(
+						schema.Argument{
+							Name: "complex",
+							Type: schema.DescribeType("ComplexInput"),
+						},
+					),
+				},
+				schema.Field{
+					Name: "arguments",
+					Type: schema.DescribeType("Arguments"),
+				},
+			),
+		}).
+		Declare(schema.Input{
+			Description: "A filter for finding a Dog",
+			Name:        "ComplexInput",
+			Fields: schema.Fields(
+				schema.Field{
+					Name: "name",
+					Type: schema.NonNullStringType,
+				},
+				schema.Field{
+					Name: "owner",
+					Type: schema.StringType,
+				},
+				schema.Field{
+					Name: "commands",
+					Type: schema.DescribeListType(schema.DescribeNonNullType("DogCommand")),
+				},
+				schema.Field{
+					Name:              "nickname",
+					Type:              schema.StringType,
+					DeprecationReason: "Use name",
+				},
 			),
+		}).
+		Declare(schema.Object{
+			Description: "Fields with arguments of every kind of type",
+			Name:        "Arguments",
+			Fields: schema.Fields(
+				schema.Field{
+					Name: "intArgField",
+					Type: schema.IntType,
+					Arguments: schema.Arguments(
+						schema.Argument{
+							Name: "intArg",
+							Type: schema.IntType,
+						},
+					),
+				},
+				schema.Field{
+					Name: "nonNullBooleanArgField",
+					Type: schema.NonNullBooleanType,
+					Arguments: schema.Arguments(
+						schema.Argument{
+							Name: "nonNullBooleanArg",
+							Type: schema.NonNullBooleanType,
+						},
+					),
+				},
+				schema.Field{
+					Name: "booleanListArgField",
+					Type: schema.DescribeListType(schema.BooleanType),
+					Arguments: schema.Arguments(
+						schema.Argument{
+							Name: "booleanListArg",
+							Type: schema.DescribeNonNullListType(schema.BooleanType),
+						},
+					),
+				},
+				schema.Field{
+					Name: "nonNullBooleanListField",
+					Type: schema.DescribeListType(schema.BooleanType),
+					Arguments: schema.Arguments(
+						schema.Argument{
+							Name: "nonNullBooleanListArg",
+							Type: schema.DescribeListType(schema.NonNullBooleanType),
+						},
+					),
+				},
+			),
+		}).
+		Directive(schema.Directive{
+			Description: "Tags a field for analytics",
+			Name:        "tag",
+			Arguments: schema.Arguments(
+				schema.Argument{
+					Name: "name",
+					Type: schema.NonNullStringType,
+				},
+			),
+			Repeatable: true,
+			Locations:  schema.Locations(schema.LocationField),
+		}).
+		Directive(schema.Directive{
+			Description: "Includes only Dogs that know a command",
+			Name:        "knows",
+			Arguments: schema.Arguments(
+				schema.Argument{
+					Name: "command",
+					Type: schema.DescribeType("DogCommand"),
+				},
+				schema.Argument{
+					Name: "filter",
+					Type: schema.DescribeType("ComplexInput"),
+				},
+				schema.Argument{
+					Name:              "strict",
+					Type:              schema.BooleanType,
+					DeprecationReason: "Dogs always know a command strictly",
+				},
+			),
+			Locations: schema.Locations(schema.LocationQuery, schema.LocationField),
 		}).Build()
 }
+
+func TestValidateUnsupportedOperationType(t *testing.T) {
+	tokens, _ := Tokenize(strings.NewReader(`mutation { dog { name } }`), true)
+	document, err := Parse(tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errors := Validate(SampleSchema, &document)
+	if len(errors) != 1 || errors[0].Error() != "Operation Type error: schema does not support mutation operations" {
+		t.Errorf("unexpected validation errors: %v", errors)
+	}
+}
+
+func TestValidateSubscriptionSingleRootField(t *testing.T) {
+	for _, test := range []ValidateTest{
+		{`subscription Barks { dogBarked { name } }`, nil},
+		{`subscription Barks { ...Fields } fragment Fields on SubscriptionRoot { dogBarked { name } other: dogBarked { name } }`, []string{
+			"Single Root Field error: subscription operation 'Barks' must select exactly one root field",
+		}},
+		{`subscription Barks { __typename dogBarked { name } }`, []string{
+			"Single Root Field error: subscription operation 'Barks' must not select __typename as a root field",
+		}},
+		{`subscription Barks { ... on SubscriptionRoot { kind: __typename } dogBarked { name } }`, []string{
+			"Single Root Field error: subscription operation 'Barks' must not select __typename as a root field",
+		}},
+		{`subscription Barks { __typename }`, []string{
+			"Single Root Field error: subscription operation 'Barks' must not select __typename as a root field",
+			"Single Root Field error: subscription operation 'Barks' must select exactly one root field",
+		}},
+	} {
+		document := parseTestDocument(t, test.query)
+
+		var actual []string
+		for _, err := range Validate(executionSchema, &document) {
+			actual = append(actual, err.Error())
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Validate(%q)\n  expected: %v\n    actual: %v", test.query, test.expected, actual)
+		}
+	}
+}
+
+type ValidateTest struct {
+	query    string
+	expected []string
+}
+
+var validateTests = []ValidateTest{
+	{`query Dog { dog { name } } query Dog { dog { nickname } }`, []string{
+		"Operation Name Uniqueness error: duplicate operation definition found: Dog",
+	}},
+	{`query { dog { name } } query Dog { dog { name } }`, []string{
+		"Lone Anonymous Operation error: more than one operation defined with anonymous operation",
+	}},
+	{`{ dog { name owner } }`, []string{
+		"Field Selection error: Object type 'Human' must have a subselection",
+	}},
+	{`{ dog { name { length } } }`, []string{
+		"Field Selection error: subselection not allowed on Scalar 'String'",
+	}},
+	{`{ dog { color } }`, []string{
+		"Field Selection error: Object type 'Dog' does not contain the field 'color'",
+	}},
+	{`{ dog { __typename ... { name } } }`, nil},
+	{`{ dog { isHousetrained(atOtherHomes: true, inside: false) } }`, []string{
+		"Field Argument error: provided invalid argument 'inside' to field 'isHousetrained'",
+	}},
+	{`{ dog { name @skip(if: true, unless: false) } }`, []string{
+		"Directive Argument error: provided invalid argument 'unless' to directive '@skip'",
+	}},
+	{`{
+		dog { doesKnowCommand(dogCommand: JUMP) isHousetrained(atOtherHomes: "yes") name @include(if: null) }
+		arguments { intArgField(intArg: 3000000000) nonNullBooleanListField(nonNullBooleanListArg: [true, null]) booleanListArgField(booleanListArg: true) }
+		findDog(complex: {name: "Rex", age: 3}) { name }
+		other: findDog(complex: {owner: "Alice", commands: SIT}) { name }
+	}`, []string{
+		"Argument Value error: argument 'dogCommand' of field 'doesKnowCommand' has invalid value: expected value of type 'DogCommand!' but found JUMP",
+		"Argument Value error: argument 'atOtherHomes' of field 'isHousetrained' has invalid value: expected value of type 'Boolean' but found \"yes\"",
+		"Argument Value error: argument 'intArg' of field 'intArgField' has invalid value: expected value of type 'Int' but found 3000000000",
+		"Argument Value error: argument 'nonNullBooleanListArg' of field 'nonNullBooleanListField' has invalid value: expected non-null value of type 'Boolean!' but found null",
+		"Argument Value error: argument 'complex' of field 'findDog' has invalid value: field 'age' is not defined by type 'ComplexInput'",
+		"Argument Value error: argument 'complex' of field 'findDog' has invalid value: field 'name' of required type 'String!' was not provided",
+		"Argument Value error: argument 'if' of directive '@include' has invalid value: expected non-null value of type 'Boolean!' but found null",
+	}},
+	{`{ dog { doesKnowCommand name @skip arguments: owner { name } } arguments { nonNullBooleanArgField } }`, []string{
+		"Required Argument error: argument 'dogCommand' of type 'DogCommand!' is required by field 'doesKnowCommand' but was not provided",
+		"Required Argument error: argument 'nonNullBooleanArg' of type 'Boolean!' is required by field 'nonNullBooleanArgField' but was not provided",
+		"Required Argument error: argument 'if' of type 'Boolean!' is required by directive '@skip' but was not provided",
+	}},
+	{`{ dog { ...Names } } fragment Names on Dog { name } fragment Names on Dog { nickname }`, []string{
+		"Fragment Name Uniqueness error: duplicate fragment definition found 'Names'",
+	}},
+	{`{ dog { ... on Wolf { name } } }`, []string{
+		"Inline Fragment Spread Type Existence error: target type 'Wolf' does not exist in the schema",
+	}},
+	{`{ dog { ...Names } } fragment Names on Wolf { name }`, []string{
+		"Fragment Spread Type Existence error: target type 'Wolf' does not exist in the schema",
+	}},
+	{`{ dog { ...Names } }`, []string{
+		"Fragment Spread error: Fragment 'Names' is not defined",
+	}},
+	{`{ dog { ...Names } } fragment Names on Dog { ...Nested } fragment Nested on Dog { name } fragment Unused on Dog { name }`, []string{
+		"Fragment Usage error: fragment 'Unused' is never used",
+	}},
+	{`{ dog { ...A } } fragment A on Dog { ...B } fragment B on Dog { owner { name } ... on Dog { ...A } } fragment C on Dog { ...C }`, []string{
+		"Fragment Usage error: fragment 'C' is never used",
+		"Fragment Cycle error: fragment 'A' spreads itself through A -> B -> A",
+		"Fragment Cycle error: fragment 'C' spreads itself through C -> C",
+	}},
+	{`{ dog { ... on Pet { name } ... on CatOrDog { ...Sentient } ...DogOrHuman } }
+	fragment DogOrHuman on DogOrHuman { __typename } fragment Sentient on Sentient { __typename }`, []string{
+		"Fragment Spread error: fragment 'Sentient' on type 'Sentient' can never be spread into type 'CatOrDog'",
+	}},
+	{`{ dog { ... on Cat { name } ...HumanOrAlien } } fragment HumanOrAlien on HumanOrAlien { __typename }`, []string{
+		"Inline Fragment Spread error: fragment on type 'Cat' can never be spread into type 'Dog'",
+		"Fragment Spread error: fragment 'HumanOrAlien' on type 'HumanOrAlien' can never be spread into type 'Dog'",
+	}},
+
+	// Field merging
+	{`{ dog { name name ... on Dog { name } owner { name } owner { __typename } } }`, nil},
+	{`{ dog { name: nickname name } }`, []string{
+		"Field Merge error: fields 'dog.name' conflict because they return conflicting types 'String' and 'String!'. Use different aliases on the fields to fetch both if this was intentional",
+	}},
+	{`{ dog { doesKnowCommand(dogCommand: SIT) doesKnowCommand(dogCommand: DOWN) } }`, []string{
+		"Field Merge error: fields 'dog.doesKnowCommand' conflict because they have differing arguments. Use different aliases on the fields to fetch both if this was intentional",
+	}},
+	{`{ dog { ...A ...B } } fragment A on Dog { command: doesKnowCommand(dogCommand: SIT) } fragment B on Dog { command: isHousetrained }`, []string{
+		"Field Merge error: fields 'dog.command' conflict because 'doesKnowCommand' and 'isHousetrained' are different fields. Use different aliases on the fields to fetch both if this was intentional",
+	}},
+	{`{ dog { owner { name } } dog { owner { name: __typename } } }`, []string{
+		"Field Merge error: fields 'dog.owner.name' conflict because 'name' and '__typename' are different fields. Use different aliases on the fields to fetch both if this was intentional",
+	}},
+	{`{ dog { ...Volumes } } fragment Volumes on Pet { ... on Dog { volume: barkVolume } ... on Cat { volume: meowVolume } }`, nil},
+	{`{ dog { ...Volumes } } fragment Volumes on Pet { ... on Dog { volume: barkVolume } ... on Cat { volume: nickname } }`, []string{
+		"Field Merge error: fields 'dog.volume' conflict because they return conflicting types 'Int' and 'String'. Use different aliases on the fields to fetch both if this was intentional",
+	}},
+
+	{`{ dog @cached { name } }`, []string{
+		"Directive error: unknown directive '@cached'",
+	}},
+	{`query Dog @skip(if: true) { dog { name } }`, []string{
+		"Directive error: directive '@skip' may not be used on QUERY",
+	}},
+	{`query Dog($name: Boolean @include(if: true)) { dog { ...Names @tag(name: "names") } } fragment Names on Dog { name }`, []string{
+		"Directive error: directive '@include' may not be used on VARIABLE_DEFINITION",
+		"Directive error: directive '@tag' may not be used on FRAGMENT_SPREAD",
+		"Variable error: variable '$name' is never used by operation 'Dog'",
+	}},
+	{`{ dog { name @include(if: true) @include(if: false) } }`, []string{
+		"Directive error: directive '@include' may only be used once at this location",
+	}},
+	{`{ dog { name @tag(name: "a") @tag(name: "b") @skip(if: false) } }`, nil},
+
+	// Variables
+	{`query Q($atOtherHomes: Boolean = true, $command: DogCommand! = SIT, $skip: Boolean!) {
+		dog @skip(if: $skip) { isHousetrained(atOtherHomes: $atOtherHomes) ...Command }
+	} fragment Command on Dog { doesKnowCommand(dogCommand: $command) }`, nil},
+	{`query Q($a: Boolean, $a: Boolean) { dog { isHousetrained(atOtherHomes: $a) } }`, []string{
+		"Variable Uniqueness error: duplicate variable definition found '$a'",
+	}},
+	{`query Q($dog: Dog, $pet: Pet, $other: Unknown, $list: [CatOrDog!]) { dog { name } }`, []string{
+		"Variable Type error: variable '$dog' cannot be of non-input Object type 'Dog'",
+		"Variable Type error: variable '$pet' cannot be of non-input Interface type 'Pet'",
+		"Variable Type error: variable '$other' has unknown type 'Unknown'",
+		"Variable Type error: variable '$list' cannot be of non-input Union type '[CatOrDog!]'",
+		"Variable error: variable '$dog' is never used by operation 'Q'",
+		"Variable error: variable '$pet' is never used by operation 'Q'",
+		"Variable error: variable '$other' is never used by operation 'Q'",
+		"Variable error: variable '$list' is never used by operation 'Q'",
+	}},
+	{`query Q($a: Boolean = "yes", $b: [DogCommand] = [SIT, JUMP], $c: Boolean! = null, $d: Boolean = $a) {
+		dog { a: isHousetrained(atOtherHomes: $a) b: isHousetrained(atOtherHomes: $d) ...on Dog { owner @skip(if: $c) { name @include(if: $b) } } }
+	}`, []string{
+		"Variable Default Value error: variable '$a' has invalid default value: expected value of type 'Boolean' but found \"yes\"",
+		"Variable Default Value error: variable '$b' has invalid default value: expected value of type 'DogCommand' but found JUMP",
+		"Variable Default Value error: variable '$c' has invalid default value: expected non-null value of type 'Boolean!' but found null",
+		"Variable Default Value error: default value of variable '$d' cannot use variable '$a'",
+		"Variable error: variable '$b' of type '[DogCommand]' cannot be used in a position expecting type 'Boolean!'",
+	}},
+	{`{ dog { isHousetrained(atOtherHomes: $atOtherHomes) } }`, []string{
+		"Variable error: variable '$atOtherHomes' is not defined by the anonymous operation",
+	}},
+	{`query A($command: DogCommand!) { dog { ...Command } } query B { dog { ...Command } }
+	fragment Command on Dog { ...Nested } fragment Nested on Dog { doesKnowCommand(dogCommand: $command) }`, []string{
+		"Variable error: variable '$command' is not defined by operation 'B'",
+	}},
+	{`query Q($int: Int!, $boolean: Boolean!, $list: [Boolean], $nonNullList: [Boolean!]!, $name: String!, $commands: [DogCommand!]!) {
+		arguments {
+			intArgField(intArg: $int)
+			nonNullBooleanArgField(nonNullBooleanArg: $boolean)
+			booleanListArgField(booleanListArg: $nonNullList)
+			nonNullBooleanListField(nonNullBooleanListArg: $nonNullList)
+			items: booleanListArgField(booleanListArg: [$boolean, true])
+			defaulted: booleanListArgField(booleanListArg: $list) @include(if: $boolean)
+		}
+		findDog(complex: {name: $name, commands: $commands}) { name }
+	}`, []string{
+		"Variable error: variable '$list' of type '[Boolean]' cannot be used in a position expecting type '[Boolean]!'",
+	}},
+	{`query Q($boolean: Boolean, $list: [Boolean], $int: Int, $name: String, $command: DogCommand) {
+		arguments {
+			nonNullBooleanArgField(nonNullBooleanArg: $boolean)
+			nonNullBooleanListField(nonNullBooleanListArg: $list)
+			booleanListArgField(booleanListArg: $boolean)
+			intArgField(intArg: $boolean)
+		}
+		dog { name @skip(if: $int) }
+		findDog(complex: {name: $name, commands: [$command]}) { name }
+	}`, []string{
+		"Variable error: variable '$boolean' of type 'Boolean' cannot be used in a position expecting type 'Boolean!'",
+		"Variable error: variable '$list' of type '[Boolean]' cannot be used in a position expecting type '[Boolean!]'",
+		"Variable error: variable '$boolean' of type 'Boolean' cannot be used in a position expecting type '[Boolean]!'",
+		"Variable error: variable '$boolean' of type 'Boolean' cannot be used in a position expecting type 'Int'",
+		"Variable error: variable '$int' of type 'Int' cannot be used in a position expecting type 'Boolean!'",
+		"Variable error: variable '$command' of type 'DogCommand' cannot be used in a position expecting type 'DogCommand!'",
+		"Variable error: variable '$name' of type 'String' cannot be used in a position expecting type 'String!'",
+	}},
+	{`query Q($boolean: Boolean = true, $list: [Boolean] = [true]) {
+		arguments { nonNullBooleanArgField(nonNullBooleanArg: $boolean) booleanListArgField(booleanListArg: $list) }
+	}`, nil},
+	{`query Q($boolean: Boolean = null) { arguments { nonNullBooleanArgField(nonNullBooleanArg: $boolean) } }`, []string{
+		"Variable error: variable '$boolean' of type 'Boolean' cannot be used in a position expecting type 'Boolean!'",
+	}},
+}
+
+func TestValidate(t *testing.T) {
+	for _, test := range validateTests {
+		document := parseTestDocument(t, test.query)
+
+		var actual []string
+		for _, err := range Validate(SampleSchema, &document) {
+			actual = append(actual, err.Error())
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Validate(%q)\n  expected: %v\n    actual: %v", test.query, test.expected, actual)
+		}
+	}
+}
+
+// Generated documents select thousands of fields with the same response key,
+// which must not be compared pairwise
+func TestValidateOverlappingFieldsLarge(t *testing.T) {
+	var query strings.Builder
+	query.WriteString("{ dog {")
+	for i := 0; i < 20000; i++ {
+		fmt.Fprintf(&query, " name alias%d: barkVolume ... on Dog { owner { name } }", i)
+	}
+	query.WriteString(" } }")
+
+	document := parseTestDocument(t, query.String())
+	if errs := Validate(SampleSchema, &document, OverlappingFieldsCanBeMerged); len(errs) > 0 {
+		t.Errorf("Expected no errors but found %v", errs)
+	}
+}
+
+// Fragments spread twice by every level of nested fragments select
+// exponentially many fields, which must not be compared one by one
+func TestValidateOverlappingFieldsNestedFragments(t *testing.T) {
+	const levels = 30
+	for _, selection := range []string{
+		"ofType { ...F%[1]d } ofType { ...F%[1]d }",
+		"a: ofType { ...F%[1]d } b: ofType { ...F%[1]d }",
+		"fields { type { ...F%[1]d } ... on __InputValue { type { ...F%[1]d } } }",
+	} {
+		var query strings.Builder
+		fmt.Fprintf(&query, `{ __type(name: "Dog") { ...F%d } } fragment F0 on __Type { name }`, levels)
+		for i := 1; i <= levels; i++ {
+			fmt.Fprintf(&query, " fragment F%d on __Type { %s }", i, fmt.Sprintf(selection, i-1))
+		}
+		document := parseTestDocument(t, query.String())
+
+		start := time.Now()
+		if errs := Validate(SampleSchema, &document, OverlappingFieldsCanBeMerged); len(errs) > 0 {
+			t.Errorf("Expected no errors but found %v", errs)
+		}
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Errorf("Validating fragments selecting %q took %s", selection, elapsed)
+		}
+	}
+}
+
+func TestValidateErrorLocations(t *testing.T) {
+	document := parseTestDocument(t, "{\n  dog {\n    color\n  }\n}")
+
+	errors := Validate(SampleSchema, &document)
+	if len(errors) != 1 || len(errors[0].Locations) != 1 {
+		t.Fatalf("unexpected validation errors: %v", errors)
+	}
+	if start := errors[0].Locations[0].Start; start.Line != 2 || start.Column != 4 {
+		t.Errorf("unexpected error location: %+v", errors[0].Locations[0])
+	}
+}
+
+// A custom Rule requiring every operation to be named
+func namedOperations(context *ValidationContext) {
+	for _, operation := range context.Document.Operations {
+		if operation.Name == "" {
+			context.Report([]Loc{operation.Loc}, "operations must be named")
+		}
+	}
+}
+
+func TestValidateCustomRules(t *testing.T) {
+	document := parseTestDocument(t, `{ dog { color } }`)
+
+	errors := Validate(SampleSchema, &document, namedOperations)
+	if len(errors) != 1 || errors[0].Error() != "operations must be named" {
+		t.Errorf("unexpected validation errors: %v", errors)
+	}
+
+	errors = Validate(SampleSchema, &document, append(SpecRules, namedOperations)...)
+	if len(errors) != 2 || errors[1].Error() != "operations must be named" {
+		t.Errorf("unexpected validation errors: %v", errors)
+	}
+}
+
+var deprecatedUsageTests = []ValidateTest{
+	{`{ dog { name doesKnowCommand(dogCommand: SIT) } }`, nil},
+	{`{ dog { nickname isHousetrained(atOtherHomes: true) doesKnowCommand(dogCommand: HEEL) } }`, []string{
+		"Deprecation warning: field 'Dog.nickname' is deprecated: Use name",
+		"Deprecation warning: argument 'atOtherHomes' of field 'Dog.isHousetrained' is deprecated: Dogs are house trained everywhere",
+		"Deprecation warning: enum value 'DogCommand.HEEL' is deprecated: Dogs no longer heel",
+	}},
+	{`{ ...Nickname } fragment Nickname on QueryRoot { dog { ... on Dog { nickname } } }`, []string{
+		"Deprecation warning: field 'Dog.nickname' is deprecated: Use name",
+	}},
+	{`{ findDog(complex: {name: "Rex", nickname: "R", commands: [SIT, HEEL]}) { name } }`, []string{
+		"Deprecation warning: enum value 'DogCommand.HEEL' is deprecated: Dogs no longer heel",
+		"Deprecation warning: input field 'ComplexInput.nickname' is deprecated: Use name",
+	}},
+	{`query @knows(command: HEEL) { dog @knows(strict: true) { name } }`, []string{
+		"Deprecation warning: enum value 'DogCommand.HEEL' is deprecated: Dogs no longer heel",
+		"Deprecation warning: argument 'strict' of directive '@knows' is deprecated: Dogs always know a command strictly",
+	}},
+	{`{ dog @knows(filter: {name: "Rex", nickname: "R", commands: HEEL}) { name } }`, []string{
+		"Deprecation warning: enum value 'DogCommand.HEEL' is deprecated: Dogs no longer heel",
+		"Deprecation warning: input field 'ComplexInput.nickname' is deprecated: Use name",
+	}},
+	{`query ($command: DogCommand! = HEEL, $filter: ComplexInput = {name: "Rex", nickname: "R"}) {
+		dog { doesKnowCommand(dogCommand: $command) }
+		findDog(complex: $filter) { name }
+	}`, []string{
+		"Deprecation warning: enum value 'DogCommand.HEEL' is deprecated: Dogs no longer heel",
+		"Deprecation warning: input field 'ComplexInput.nickname' is deprecated: Use name",
+	}},
+}
+
+func TestFindDeprecatedUsages(t *testing.T) {
+	for _, test := range deprecatedUsageTests {
+		document := parseTestDocument(t, test.query)
+
+		var actual []string
+		for _, err := range FindDeprecatedUsages(SampleSchema, &document) {
+			actual = append(actual, err.Error())
+		}
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("FindDeprecatedUsages(%q)\n  expected: %v\n    actual: %v", test.query, test.expected, actual)
+		}
+	}
+
+	// Deprecated usages are not errors
+	document := parseTestDocument(t, `{ dog { nickname } }`)
+	if errors := Validate(SampleSchema, &document); len(errors) > 0 {
+		t.Errorf("unexpected validation errors: %v", errors)
+	}
+}